@@ -4,8 +4,12 @@ go 1.14
 
 require (
 	github.com/coreos/prometheus-operator v0.41.1
+	github.com/evanphx/json-patch v4.9.0+incompatible
+	github.com/fsnotify/fsnotify v1.4.9
 	github.com/openshift/api v0.0.0-20200803131051-87466835fcc0
 	github.com/operator-framework/api v0.3.12
+	google.golang.org/protobuf v1.25.0
+	gopkg.in/yaml.v3 v3.0.0-20200603094226-e3079894b1e8
 	k8s.io/api v0.20.2
 	k8s.io/apiextensions-apiserver v0.20.2
 	k8s.io/apimachinery v0.20.2
@@ -13,6 +17,7 @@ require (
 	k8s.io/kube-aggregator v0.20.2
 	k8s.io/kubernetes v1.20.2
 	k8s.io/metrics v0.20.2
+	sigs.k8s.io/yaml v1.2.0
 )
 
 replace (
@@ -0,0 +1,59 @@
+/**
+ * Copyright (C) 2015 Red Hat, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *         http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package initializers
+
+import (
+	"sort"
+
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+// Canonicalize fixes wildcard placement and sorts and deduplicates the
+// apiGroups, apiVersions and resources of every rule, none of which are
+// order sensitive. Initializer and rule order is preserved.
+func Canonicalize(cfg *InitializerConfiguration) {
+	FixWildcards(cfg)
+	for i := range cfg.Initializers {
+		rules := cfg.Initializers[i].Rules
+		for j := range rules {
+			rules[j].APIGroups = sortedSet(rules[j].APIGroups)
+			rules[j].APIVersions = sortedSet(rules[j].APIVersions)
+			rules[j].Resources = sortedSet(rules[j].Resources)
+		}
+	}
+}
+
+// CanonicalizeAndValidate returns the canonical form of cfg together with the
+// validation errors that canonicalization could not fix. cfg is not modified.
+func CanonicalizeAndValidate(cfg InitializerConfiguration) (InitializerConfiguration, field.ErrorList) {
+	canonical := cfg.DeepCopy()
+	Canonicalize(canonical)
+	return *canonical, ValidateInitializerConfiguration(*canonical)
+}
+
+func sortedSet(values []string) []string {
+	if len(values) == 0 {
+		return values
+	}
+	sort.Strings(values)
+	out := values[:1]
+	for _, v := range values[1:] {
+		if v != out[len(out)-1] {
+			out = append(out, v)
+		}
+	}
+	return out
+}
@@ -0,0 +1,50 @@
+/**
+ * Copyright (C) 2015 Red Hat, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *         http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package initializers
+
+import (
+	"reflect"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestCanonicalizeAndValidate(t *testing.T) {
+	cfg := InitializerConfiguration{
+		ObjectMeta: metav1.ObjectMeta{Name: "example"},
+		Initializers: []Initializer{{
+			Name: "bad",
+			Rules: []Rule{{
+				APIGroups:   []string{"apps", "*"},
+				APIVersions: []string{"v1beta1", "v1", "v1"},
+				Resources:   []string{"deployments", "*"},
+			}},
+		}},
+	}
+	original := *cfg.DeepCopy()
+	canonical, errs := CanonicalizeAndValidate(cfg)
+
+	want := Rule{APIGroups: []string{"*"}, APIVersions: []string{"v1", "v1beta1"}, Resources: []string{"*"}}
+	if got := canonical.Initializers[0].Rules[0]; !reflect.DeepEqual(got, want) {
+		t.Errorf("expected %#v, got %#v", want, got)
+	}
+	if len(errs) != 1 || errs[0].Field != "initializers[0].name" {
+		t.Errorf("expected only the invalid initializer name to remain, got %v", errs)
+	}
+	if !reflect.DeepEqual(cfg, original) {
+		t.Error("expected cfg not to be modified")
+	}
+}
@@ -0,0 +1,174 @@
+/**
+ * Copyright (C) 2015 Red Hat, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *         http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package initializers
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+)
+
+const compactFormatVersion = 1
+
+var errCompactTruncated = errors.New("truncated compact encoding")
+
+// EncodeCompact encodes cfg as a version byte followed by uvarint length
+// prefixed fields: apiVersion, kind, the protobuf encoded metadata, then the
+// initializers with their rules in order.
+func EncodeCompact(cfg InitializerConfiguration) ([]byte, error) {
+	meta, err := cfg.ObjectMeta.Marshal()
+	if err != nil {
+		return nil, fmt.Errorf("encoding metadata: %v", err)
+	}
+	b := []byte{compactFormatVersion}
+	b = appendCompactString(b, cfg.APIVersion)
+	b = appendCompactString(b, cfg.Kind)
+	b = appendCompactBytes(b, meta)
+	b = appendUvarint(b, uint64(len(cfg.Initializers)))
+	for _, initializer := range cfg.Initializers {
+		b = appendCompactString(b, initializer.Name)
+		b = appendUvarint(b, uint64(len(initializer.Rules)))
+		for _, rule := range initializer.Rules {
+			b = appendCompactStrings(b, rule.APIGroups)
+			b = appendCompactStrings(b, rule.APIVersions)
+			b = appendCompactStrings(b, rule.Resources)
+		}
+	}
+	return b, nil
+}
+
+// DecodeCompact decodes the output of EncodeCompact, rejecting other format
+// versions, truncated input and trailing bytes.
+func DecodeCompact(data []byte) (InitializerConfiguration, error) {
+	var cfg InitializerConfiguration
+	if len(data) == 0 {
+		return cfg, errCompactTruncated
+	}
+	if data[0] != compactFormatVersion {
+		return cfg, fmt.Errorf("unsupported compact format version %d", data[0])
+	}
+	r := compactReader{data: data[1:]}
+	cfg.APIVersion = r.string()
+	cfg.Kind = r.string()
+	meta := r.bytes()
+	if r.err == nil {
+		if err := cfg.ObjectMeta.Unmarshal(meta); err != nil {
+			return cfg, fmt.Errorf("decoding metadata: %v", err)
+		}
+	}
+	if n := r.count(); n > 0 {
+		cfg.Initializers = make([]Initializer, n)
+	}
+	for i := range cfg.Initializers {
+		cfg.Initializers[i].Name = r.string()
+		if n := r.count(); n > 0 {
+			cfg.Initializers[i].Rules = make([]Rule, n)
+		}
+		for j := range cfg.Initializers[i].Rules {
+			rule := &cfg.Initializers[i].Rules[j]
+			rule.APIGroups = r.strings()
+			rule.APIVersions = r.strings()
+			rule.Resources = r.strings()
+		}
+	}
+	if r.err == nil && len(r.data) > 0 {
+		r.err = fmt.Errorf("%d unexpected trailing bytes", len(r.data))
+	}
+	return cfg, r.err
+}
+
+func appendUvarint(b []byte, v uint64) []byte {
+	var buf [binary.MaxVarintLen64]byte
+	n := binary.PutUvarint(buf[:], v)
+	return append(b, buf[:n]...)
+}
+
+func appendCompactBytes(b []byte, value []byte) []byte {
+	b = appendUvarint(b, uint64(len(value)))
+	return append(b, value...)
+}
+
+func appendCompactString(b []byte, value string) []byte {
+	b = appendUvarint(b, uint64(len(value)))
+	return append(b, value...)
+}
+
+func appendCompactStrings(b []byte, values []string) []byte {
+	b = appendUvarint(b, uint64(len(values)))
+	for _, v := range values {
+		b = appendCompactString(b, v)
+	}
+	return b
+}
+
+// compactReader consumes a compact encoding, recording the first error and
+// returning zero values from then on.
+type compactReader struct {
+	data []byte
+	err  error
+}
+
+func (r *compactReader) uvarint() uint64 {
+	if r.err != nil {
+		return 0
+	}
+	v, n := binary.Uvarint(r.data)
+	if n <= 0 {
+		r.err = errCompactTruncated
+		return 0
+	}
+	r.data = r.data[n:]
+	return v
+}
+
+// count reads a length and rejects values that cannot fit in the remaining
+// data, since every counted element takes at least one byte.
+func (r *compactReader) count() int {
+	n := r.uvarint()
+	if n > uint64(len(r.data)) {
+		if r.err == nil {
+			r.err = errCompactTruncated
+		}
+		return 0
+	}
+	return int(n)
+}
+
+func (r *compactReader) bytes() []byte {
+	n := r.count()
+	if r.err != nil {
+		return nil
+	}
+	v := r.data[:n]
+	r.data = r.data[n:]
+	return v
+}
+
+func (r *compactReader) string() string {
+	return string(r.bytes())
+}
+
+func (r *compactReader) strings() []string {
+	n := r.count()
+	if n == 0 {
+		return nil
+	}
+	values := make([]string, n)
+	for i := range values {
+		values[i] = r.string()
+	}
+	return values
+}
@@ -0,0 +1,84 @@
+/**
+ * Copyright (C) 2015 Red Hat, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *         http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package initializers
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/api/equality"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestCompactRoundTrip(t *testing.T) {
+	cfg := InitializerConfiguration{
+		TypeMeta: metav1.TypeMeta{APIVersion: SchemeGroupVersion.String(), Kind: Kind},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "example",
+			Labels:      map[string]string{"team": "a"},
+			Annotations: map[string]string{"owner": "me"},
+		},
+		Initializers: []Initializer{
+			{Name: "a.example.com", Rules: []Rule{
+				{APIGroups: []string{"", "apps"}, APIVersions: []string{"v1"}, Resources: []string{"pods", "deployments"}},
+				{APIGroups: []string{"batch"}, APIVersions: []string{"v1", "v1beta1"}, Resources: []string{"jobs", "cronjobs"}},
+			}},
+			{Name: "b.example.com", Rules: []Rule{
+				{APIGroups: []string{"*"}, APIVersions: []string{"*"}, Resources: []string{"*/scale"}},
+			}},
+		},
+	}
+	data, err := EncodeCompact(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	decoded, err := DecodeCompact(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !equality.Semantic.DeepEqual(cfg, decoded) {
+		t.Errorf("expected %#v, got %#v", cfg, decoded)
+	}
+	if !reflect.DeepEqual(cfg.Initializers, decoded.Initializers) {
+		t.Errorf("expected initializers %#v, got %#v", cfg.Initializers, decoded.Initializers)
+	}
+
+	encoded, err := json.Marshal(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(data) >= len(encoded) {
+		t.Errorf("expected the compact encoding (%d bytes) to be smaller than JSON (%d bytes)", len(data), len(encoded))
+	}
+}
+
+func TestDecodeCompactErrors(t *testing.T) {
+	data, err := EncodeCompact(InitializerConfiguration{Initializers: []Initializer{{Name: "a.example.com"}}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for name, input := range map[string][]byte{
+		"empty":     nil,
+		"version":   append([]byte{compactFormatVersion + 1}, data[1:]...),
+		"truncated": data[:len(data)-2],
+		"trailing":  append(append([]byte{}, data...), 0),
+	} {
+		if _, err := DecodeCompact(input); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+}
@@ -0,0 +1,99 @@
+/**
+ * Copyright (C) 2015 Red Hat, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *         http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package initializers
+
+import (
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// Isomorphic reports whether a and b declare the same rules in the same
+// positions, ignoring config and initializer names.
+func Isomorphic(a, b InitializerConfiguration) bool {
+	if len(a.Initializers) != len(b.Initializers) {
+		return false
+	}
+	for i := range a.Initializers {
+		ra, rb := a.Initializers[i].Rules, b.Initializers[i].Rules
+		if len(ra) != len(rb) {
+			return false
+		}
+		for j := range ra {
+			if !rulesEqual(ra[j], rb[j]) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+func rulesEqual(a, b Rule) bool {
+	return stringsEqual(a.APIGroups, b.APIGroups) &&
+		stringsEqual(a.APIVersions, b.APIVersions) &&
+		stringsEqual(a.Resources, b.Resources)
+}
+
+func stringsEqual(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// CoverageDiff compares the GVRs of universe matched by a and b, returning in
+// universe order those only a matches and those only b matches.
+func CoverageDiff(a, b InitializerConfiguration, universe []schema.GroupVersionResource) (lostGVRs, gainedGVRs []schema.GroupVersionResource) {
+	seen := make(map[schema.GroupVersionResource]bool, len(universe))
+	for _, gvr := range universe {
+		if seen[gvr] {
+			continue
+		}
+		seen[gvr] = true
+		inA, inB := configMatches(a, gvr), configMatches(b, gvr)
+		switch {
+		case inA && !inB:
+			lostGVRs = append(lostGVRs, gvr)
+		case inB && !inA:
+			gainedGVRs = append(gainedGVRs, gvr)
+		}
+	}
+	return lostGVRs, gainedGVRs
+}
+
+// IsSuperset reports whether a intercepts every GVR b intercepts. Wildcards in
+// b are only covered by matching wildcards in a, since no finite set of
+// concrete values covers them.
+func IsSuperset(a, b InitializerConfiguration) bool {
+	for _, initializer := range b.Initializers {
+		for _, rule := range initializer.Rules {
+			for _, group := range rule.APIGroups {
+				for _, version := range rule.APIVersions {
+					for _, resource := range rule.Resources {
+						gvr := schema.GroupVersionResource{Group: group, Version: version, Resource: resource}
+						if !configMatches(a, gvr) {
+							return false
+						}
+					}
+				}
+			}
+		}
+	}
+	return true
+}
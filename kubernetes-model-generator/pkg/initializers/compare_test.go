@@ -0,0 +1,109 @@
+/**
+ * Copyright (C) 2015 Red Hat, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *         http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package initializers
+
+import (
+	"reflect"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+func TestIsomorphic(t *testing.T) {
+	pods := Rule{APIGroups: []string{""}, APIVersions: []string{"v1"}, Resources: []string{"pods"}}
+	deployments := Rule{APIGroups: []string{"apps"}, APIVersions: []string{"v1"}, Resources: []string{"deployments"}}
+	a := InitializerConfiguration{
+		ObjectMeta:   metav1.ObjectMeta{Name: "a"},
+		Initializers: []Initializer{{Name: "a.example.com", Rules: []Rule{pods, deployments}}},
+	}
+	b := InitializerConfiguration{
+		ObjectMeta:   metav1.ObjectMeta{Name: "b"},
+		Initializers: []Initializer{{Name: "b.other.io", Rules: []Rule{pods, deployments}}},
+	}
+	if !Isomorphic(a, b) {
+		t.Error("expected configs differing only in names to be isomorphic")
+	}
+
+	fewer := *b.DeepCopy()
+	fewer.Initializers[0].Rules = fewer.Initializers[0].Rules[:1]
+	if Isomorphic(a, fewer) {
+		t.Error("expected configs with different rule counts not to be isomorphic")
+	}
+
+	reordered := *b.DeepCopy()
+	reordered.Initializers[0].Rules = []Rule{deployments, pods}
+	if Isomorphic(a, reordered) {
+		t.Error("expected configs with rules in other positions not to be isomorphic")
+	}
+}
+
+func TestCoverageDiff(t *testing.T) {
+	a := InitializerConfiguration{Initializers: []Initializer{{Name: "a.example.com", Rules: []Rule{
+		{APIGroups: []string{"apps"}, APIVersions: []string{"*"}, Resources: []string{"*"}},
+		{APIGroups: []string{""}, APIVersions: []string{"v1"}, Resources: []string{"pods"}},
+	}}}}
+	b := InitializerConfiguration{Initializers: []Initializer{{Name: "b.example.com", Rules: []Rule{
+		{APIGroups: []string{"apps"}, APIVersions: []string{"v1"}, Resources: []string{"*/*"}},
+		{APIGroups: []string{"", "batch"}, APIVersions: []string{"v1"}, Resources: []string{"pods", "jobs"}},
+	}}}}
+	lost, gained := CoverageDiff(a, b, testUniverse)
+	wantLost := []schema.GroupVersionResource{{Group: "apps", Version: "v1beta1", Resource: "deployments"}}
+	wantGained := []schema.GroupVersionResource{
+		{Group: "apps", Version: "v1", Resource: "deployments/scale"},
+		{Group: "batch", Version: "v1", Resource: "jobs"},
+	}
+	if !reflect.DeepEqual(lost, wantLost) {
+		t.Errorf("expected lost %v, got %v", wantLost, lost)
+	}
+	if !reflect.DeepEqual(gained, wantGained) {
+		t.Errorf("expected gained %v, got %v", wantGained, gained)
+	}
+	if lost, gained := CoverageDiff(a, a, testUniverse); lost != nil || gained != nil {
+		t.Errorf("expected no difference, got %v and %v", lost, gained)
+	}
+}
+
+func TestIsSuperset(t *testing.T) {
+	narrow := InitializerConfiguration{Initializers: []Initializer{{Name: "b.example.com", Rules: []Rule{
+		{APIGroups: []string{"apps"}, APIVersions: []string{"v1"}, Resources: []string{"deployments", "deployments/scale"}},
+	}}}}
+	broad := InitializerConfiguration{Initializers: []Initializer{{Name: "a.example.com", Rules: []Rule{
+		{APIGroups: []string{"apps"}, APIVersions: []string{"*"}, Resources: []string{"*/*"}},
+	}}}}
+	bare := InitializerConfiguration{Initializers: []Initializer{{Name: "c.example.com", Rules: []Rule{
+		{APIGroups: []string{"apps"}, APIVersions: []string{"*"}, Resources: []string{"*"}},
+	}}}}
+	tests := []struct {
+		name string
+		a, b InitializerConfiguration
+		want bool
+	}{
+		{name: "proper superset", a: broad, b: narrow, want: true},
+		{name: "equal", a: narrow, b: narrow, want: true},
+		{name: "subset", a: narrow, b: broad, want: false},
+		// "*" leaves out the deployments/scale subresource.
+		{name: "bare wildcard", a: bare, b: narrow, want: false},
+		{name: "wildcard covered by a broader wildcard", a: broad, b: bare, want: true},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := IsSuperset(tc.a, tc.b); got != tc.want {
+				t.Errorf("expected %v, got %v", tc.want, got)
+			}
+		})
+	}
+}
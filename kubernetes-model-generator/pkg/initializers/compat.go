@@ -0,0 +1,43 @@
+/**
+ * Copyright (C) 2015 Red Hat, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *         http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package initializers
+
+import (
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/util/version"
+)
+
+var (
+	introducedIn = version.MustParseGeneric("1.7.0")
+	removedIn    = version.MustParseGeneric("1.14.0")
+)
+
+// CompatibleWith reports whether a Kubernetes version serves
+// InitializerConfiguration, with the reason when it does not.
+func CompatibleWith(v string) (bool, string) {
+	parsed, err := version.ParseGeneric(v)
+	if err != nil {
+		return false, fmt.Sprintf("invalid Kubernetes version %q: %v", v, err)
+	}
+	if parsed.LessThan(introducedIn) {
+		return false, fmt.Sprintf("%s/%s was introduced in Kubernetes %s", SchemeGroupVersion, Resource, introducedIn)
+	}
+	if !parsed.LessThan(removedIn) {
+		return false, fmt.Sprintf("%s/%s was removed in Kubernetes %s, migrate to admission webhooks", SchemeGroupVersion, Resource, removedIn)
+	}
+	return true, ""
+}
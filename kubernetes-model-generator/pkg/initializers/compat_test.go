@@ -0,0 +1,50 @@
+/**
+ * Copyright (C) 2015 Red Hat, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *         http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package initializers
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestCompatibleWith(t *testing.T) {
+	tests := []struct {
+		version    string
+		want       bool
+		wantReason string
+	}{
+		{version: "1.9", want: true},
+		{version: "v1.13.4", want: true},
+		{version: "1.6.0", want: false, wantReason: "introduced in Kubernetes 1.7.0"},
+		{version: "1.14.0", want: false, wantReason: "removed in Kubernetes 1.14.0, migrate to admission webhooks"},
+		{version: "1.20", want: false, wantReason: "removed in Kubernetes 1.14.0"},
+		{version: "latest", want: false, wantReason: `invalid Kubernetes version "latest"`},
+	}
+	for _, tc := range tests {
+		t.Run(tc.version, func(t *testing.T) {
+			got, reason := CompatibleWith(tc.version)
+			if got != tc.want {
+				t.Errorf("expected %v, got %v (%s)", tc.want, got, reason)
+			}
+			if tc.want && reason != "" {
+				t.Errorf("expected no reason, got %q", reason)
+			}
+			if !strings.Contains(reason, tc.wantReason) {
+				t.Errorf("expected reason containing %q, got %q", tc.wantReason, reason)
+			}
+		})
+	}
+}
@@ -0,0 +1,68 @@
+/**
+ * Copyright (C) 2015 Red Hat, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *         http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package initializers
+
+import (
+	"reflect"
+)
+
+// FieldCoverage reports, for every field documented in the SwaggerDoc maps,
+// whether it is set anywhere in cfg. Nested fields are keyed by their JSON
+// path, such as initializers.rules.apiGroups.
+func FieldCoverage(cfg InitializerConfiguration) map[string]bool {
+	var initializers, rules []reflect.Value
+	for _, initializer := range cfg.Initializers {
+		initializers = append(initializers, reflect.ValueOf(initializer))
+		for _, rule := range initializer.Rules {
+			rules = append(rules, reflect.ValueOf(rule))
+		}
+	}
+	coverage := make(map[string]bool)
+	markCoverage(coverage, "", reflect.TypeOf(cfg), []reflect.Value{reflect.ValueOf(cfg)}, cfg.SwaggerDoc())
+	markCoverage(coverage, "initializers.", reflect.TypeOf(Initializer{}), initializers, Initializer{}.SwaggerDoc())
+	markCoverage(coverage, "initializers.rules.", reflect.TypeOf(Rule{}), rules, Rule{}.SwaggerDoc())
+	return coverage
+}
+
+func markCoverage(coverage map[string]bool, prefix string, t reflect.Type, values []reflect.Value, docs map[string]string) {
+	fields := jsonFields(t)
+	for name := range docs {
+		if name == "" {
+			continue
+		}
+		key := prefix + name
+		coverage[key] = false
+		f, ok := fields[name]
+		if !ok {
+			continue
+		}
+		for _, v := range values {
+			if !isEmptyValue(v.FieldByIndex(f.Index)) {
+				coverage[key] = true
+				break
+			}
+		}
+	}
+}
+
+// isEmptyValue treats empty slices and maps like nil ones.
+func isEmptyValue(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.Slice, reflect.Map:
+		return v.Len() == 0
+	}
+	return v.IsZero()
+}
@@ -0,0 +1,55 @@
+/**
+ * Copyright (C) 2015 Red Hat, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *         http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package initializers
+
+import (
+	"reflect"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestFieldCoverage(t *testing.T) {
+	cfg := InitializerConfiguration{
+		ObjectMeta: metav1.ObjectMeta{Name: "example"},
+		Initializers: []Initializer{
+			{Name: "a.example.com"},
+			{Name: "b.example.com", Rules: []Rule{{APIGroups: []string{}, APIVersions: []string{"v1"}, Resources: []string{"pods"}}}},
+		},
+	}
+	want := map[string]bool{
+		"metadata":                       true,
+		"initializers":                   true,
+		"initializers.name":              true,
+		"initializers.rules":             true,
+		"initializers.rules.apiGroups":   false,
+		"initializers.rules.apiVersions": true,
+		"initializers.rules.resources":   true,
+	}
+	if got := FieldCoverage(cfg); !reflect.DeepEqual(got, want) {
+		t.Errorf("expected %v, got %v", want, got)
+	}
+
+	empty := FieldCoverage(InitializerConfiguration{})
+	for key, covered := range empty {
+		if covered {
+			t.Errorf("expected %s not to be covered in an empty config", key)
+		}
+	}
+	if len(empty) != len(want) {
+		t.Errorf("expected %d fields, got %v", len(want), empty)
+	}
+}
@@ -0,0 +1,102 @@
+/**
+ * Copyright (C) 2015 Red Hat, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *         http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package initializers
+
+import (
+	"bufio"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strings"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+var csvHeader = []string{"apiGroups", "apiVersions", "resources"}
+
+// FromCSV reads rules from rows of apiGroups,apiVersions,resources, with
+// multiple values in a cell separated by semicolons, into a config named after
+// initializerName holding a single initializer. A leading header row is
+// skipped, as are blank lines. An empty apiGroups cell stands for the core
+// group.
+func FromCSV(r io.Reader, initializerName string) (InitializerConfiguration, error) {
+	scanner := bufio.NewScanner(r)
+	var rules []Rule
+	for line := 1; scanner.Scan(); line++ {
+		if strings.TrimSpace(scanner.Text()) == "" {
+			continue
+		}
+		// Rows are parsed one line at a time so errors carry the source line.
+		reader := csv.NewReader(strings.NewReader(scanner.Text()))
+		reader.TrimLeadingSpace = true
+		record, err := reader.Read()
+		if perr, ok := err.(*csv.ParseError); ok {
+			err = perr.Err
+		}
+		if err != nil {
+			return InitializerConfiguration{}, fmt.Errorf("line %d: %v", line, err)
+		}
+		if len(rules) == 0 && isCSVHeader(record) {
+			continue
+		}
+		if len(record) != len(csvHeader) {
+			return InitializerConfiguration{}, fmt.Errorf("line %d: expected %d fields, got %d", line, len(csvHeader), len(record))
+		}
+		rule := Rule{
+			APIGroups:   splitCell(record[0]),
+			APIVersions: splitCell(record[1]),
+			Resources:   splitCell(record[2]),
+		}
+		for i, values := range [][]string{rule.APIVersions, rule.Resources} {
+			if len(values) == 1 && values[0] == "" {
+				return InitializerConfiguration{}, fmt.Errorf("line %d: %s must not be empty", line, csvHeader[i+1])
+			}
+		}
+		rules = append(rules, rule)
+	}
+	if err := scanner.Err(); err != nil {
+		return InitializerConfiguration{}, fmt.Errorf("reading CSV: %v", err)
+	}
+
+	return InitializerConfiguration{
+		TypeMeta:   metav1.TypeMeta{APIVersion: SchemeGroupVersion.String(), Kind: Kind},
+		ObjectMeta: metav1.ObjectMeta{Name: initializerName},
+		Initializers: []Initializer{{
+			Name:  initializerName,
+			Rules: rules,
+		}},
+	}, nil
+}
+
+func isCSVHeader(record []string) bool {
+	if len(record) != len(csvHeader) {
+		return false
+	}
+	for i, name := range csvHeader {
+		if !strings.EqualFold(strings.TrimSpace(record[i]), name) {
+			return false
+		}
+	}
+	return true
+}
+
+func splitCell(cell string) []string {
+	values := strings.Split(cell, ";")
+	for i := range values {
+		values[i] = strings.TrimSpace(values[i])
+	}
+	return values
+}
@@ -0,0 +1,54 @@
+/**
+ * Copyright (C) 2015 Red Hat, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *         http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package initializers
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestFromCSV(t *testing.T) {
+	input := `apiGroups,apiVersions,resources
+apps;batch,v1,deployments;jobs
+
+,v1,pods; services
+`
+	cfg, err := FromCSV(strings.NewReader(input), "csv.example.com")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cfg.Name != "csv.example.com" || cfg.Kind != Kind || len(cfg.Initializers) != 1 || cfg.Initializers[0].Name != "csv.example.com" {
+		t.Fatalf("unexpected config %+v", cfg)
+	}
+	want := []Rule{
+		{APIGroups: []string{"apps", "batch"}, APIVersions: []string{"v1"}, Resources: []string{"deployments", "jobs"}},
+		{APIGroups: []string{""}, APIVersions: []string{"v1"}, Resources: []string{"pods", "services"}},
+	}
+	if got := cfg.Initializers[0].Rules; !reflect.DeepEqual(got, want) {
+		t.Errorf("expected %#v, got %#v", want, got)
+	}
+
+	for name, tc := range map[string]struct{ input, wantErr string }{
+		"missing field":  {"apiGroups,apiVersions,resources\n\napps,v1\n", "line 3: expected 3 fields, got 2"},
+		"empty versions": {"apps,,deployments\n", "line 1: apiVersions must not be empty"},
+		"bad quoting":    {"apps,v1,pods\napps,\"v1,deployments\n", "line 2: "},
+	} {
+		if _, err := FromCSV(strings.NewReader(tc.input), "csv.example.com"); err == nil || !strings.HasPrefix(err.Error(), tc.wantErr) {
+			t.Errorf("%s: expected an error starting with %q, got %v", name, tc.wantErr, err)
+		}
+	}
+}
@@ -0,0 +1,199 @@
+/**
+ * Copyright (C) 2015 Red Hat, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *         http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package initializers
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	k8syaml "sigs.k8s.io/yaml"
+)
+
+// CommitInfo identifies the commit a manifest was read from.
+type CommitInfo struct {
+	SHA       string
+	Author    string
+	Timestamp time.Time
+}
+
+// ConfigWithProvenance pairs a decoded config with the commit it was read from.
+type ConfigWithProvenance struct {
+	Config InitializerConfiguration
+	Commit CommitInfo
+}
+
+var jsonUnmarshalerType = reflect.TypeOf((*json.Unmarshaler)(nil)).Elem()
+
+// DecodeWithPositions decodes a YAML or JSON manifest, reporting the line and
+// column of the offending node when a field cannot be decoded.
+func DecodeWithPositions(data []byte) (InitializerConfiguration, error) {
+	var cfg InitializerConfiguration
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return cfg, err
+	}
+	if len(doc.Content) > 0 {
+		if err := locateDecodeError(doc.Content[0], reflect.TypeOf(cfg), nil); err != nil {
+			return cfg, err
+		}
+	}
+	if err := k8syaml.Unmarshal(data, &cfg); err != nil {
+		return cfg, err
+	}
+	return cfg, nil
+}
+
+// locateDecodeError walks node alongside t, descending as far as the node
+// structure allows so the reported position is that of the innermost bad value.
+func locateDecodeError(node *yaml.Node, t reflect.Type, path *field.Path) error {
+	for node.Kind == yaml.AliasNode {
+		node = node.Alias
+	}
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if node.Kind == yaml.ScalarNode && node.Tag == "!!null" {
+		return nil
+	}
+	if reflect.PtrTo(t).Implements(jsonUnmarshalerType) {
+		return decodeNode(node, t, path)
+	}
+	switch t.Kind() {
+	case reflect.Struct:
+		if node.Kind != yaml.MappingNode {
+			return positionError(node, path, fmt.Errorf("expected a mapping, got %s", nodeKind(node)))
+		}
+		fields := jsonFields(t)
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			name := node.Content[i].Value
+			if f, ok := fields[name]; ok {
+				if err := locateDecodeError(node.Content[i+1], f.Type, path.Child(name)); err != nil {
+					return err
+				}
+			}
+		}
+		return nil
+	case reflect.Slice:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return decodeNode(node, t, path)
+		}
+		if node.Kind != yaml.SequenceNode {
+			return positionError(node, path, fmt.Errorf("expected a sequence, got %s", nodeKind(node)))
+		}
+		for i, item := range node.Content {
+			if err := locateDecodeError(item, t.Elem(), path.Index(i)); err != nil {
+				return err
+			}
+		}
+		return nil
+	case reflect.Map:
+		if node.Kind != yaml.MappingNode {
+			return positionError(node, path, fmt.Errorf("expected a mapping, got %s", nodeKind(node)))
+		}
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			if err := locateDecodeError(node.Content[i+1], t.Elem(), path.Key(node.Content[i].Value)); err != nil {
+				return err
+			}
+		}
+		return nil
+	default:
+		return decodeNode(node, t, path)
+	}
+}
+
+// decodeNode round-trips node through JSON into a fresh t, mirroring how
+// sigs.k8s.io/yaml decodes the whole document.
+func decodeNode(node *yaml.Node, t reflect.Type, path *field.Path) error {
+	var value interface{}
+	if err := node.Decode(&value); err != nil {
+		return positionError(node, path, err)
+	}
+	data, err := json.Marshal(value)
+	if err != nil {
+		return positionError(node, path, err)
+	}
+	if err := json.Unmarshal(data, reflect.New(t).Interface()); err != nil {
+		return positionError(node, path, err)
+	}
+	return nil
+}
+
+// jsonFields indexes the fields of t by JSON name, flattening inlined structs
+// and adjusting their Index to be relative to t.
+func jsonFields(t reflect.Type) map[string]reflect.StructField {
+	fields := make(map[string]reflect.StructField)
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		tag := f.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name := strings.Split(tag, ",")[0]
+		if f.Anonymous && name == "" {
+			for k, v := range jsonFields(f.Type) {
+				v.Index = append([]int{i}, v.Index...)
+				fields[k] = v
+			}
+			continue
+		}
+		if name == "" {
+			name = f.Name
+		}
+		fields[name] = f
+	}
+	return fields
+}
+
+func positionError(node *yaml.Node, path *field.Path, err error) error {
+	if path == nil {
+		return fmt.Errorf("line %d, column %d: %v", node.Line, node.Column, err)
+	}
+	return fmt.Errorf("line %d, column %d: %s: %v", node.Line, node.Column, path, err)
+}
+
+func nodeKind(node *yaml.Node) string {
+	switch node.Kind {
+	case yaml.MappingNode:
+		return "mapping"
+	case yaml.SequenceNode:
+		return "sequence"
+	default:
+		return "scalar"
+	}
+}
+
+// DecodeFromGitBlob decodes an InitializerConfiguration manifest read from a
+// Git blob and pairs it with the commit it came from. The commit is not
+// recorded on the config itself. Configs failing
+// ValidateInitializerConfiguration are rejected with the aggregated errors.
+func DecodeFromGitBlob(data []byte, commit CommitInfo) (ConfigWithProvenance, error) {
+	cfg, err := DecodeWithPositions(data)
+	if err != nil {
+		return ConfigWithProvenance{}, fmt.Errorf("decoding blob at commit %s: %v", commit.SHA, err)
+	}
+	if cfg.Kind != Kind {
+		return ConfigWithProvenance{}, fmt.Errorf("blob at commit %s is not an %s, got kind %q", commit.SHA, Kind, cfg.Kind)
+	}
+	if errs := ValidateInitializerConfiguration(cfg); len(errs) != 0 {
+		return ConfigWithProvenance{}, fmt.Errorf("blob at commit %s is invalid: %v", commit.SHA, errs.ToAggregate())
+	}
+	return ConfigWithProvenance{Config: cfg, Commit: commit}, nil
+}
@@ -0,0 +1,101 @@
+/**
+ * Copyright (C) 2015 Red Hat, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *         http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package initializers
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestDecodeWithPositions(t *testing.T) {
+	manifest := `apiVersion: admissionregistration.k8s.io/v1alpha1
+kind: InitializerConfiguration
+metadata:
+  name: example
+initializers:
+- name: a.example.com
+  rules:
+  - apiGroups: apps
+    apiVersions: ["v1"]
+    resources: ["deployments"]
+`
+	_, err := DecodeWithPositions([]byte(manifest))
+	if err == nil {
+		t.Fatal("expected an error for a scalar apiGroups")
+	}
+	if !strings.HasPrefix(err.Error(), "line 8, column 16: ") {
+		t.Errorf("expected the error at line 8, column 16, got %q", err)
+	}
+	if !strings.Contains(err.Error(), "initializers[0].rules[0].apiGroups") {
+		t.Errorf("expected the error to name the field path, got %q", err)
+	}
+
+	fixed := strings.Replace(manifest, "apiGroups: apps", `apiGroups: ["apps"]`, 1)
+	cfg, err := DecodeWithPositions([]byte(fixed))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := cfg.Initializers[0].Rules[0].APIGroups; len(got) != 1 || got[0] != "apps" {
+		t.Errorf("expected apiGroups [apps], got %v", got)
+	}
+}
+
+func TestDecodeFromGitBlob(t *testing.T) {
+	blob := `apiVersion: admissionregistration.k8s.io/v1alpha1
+kind: InitializerConfiguration
+metadata:
+  name: example
+  annotations:
+    owner: me
+initializers:
+- name: a.example.com
+  rules:
+  - apiGroups: [""]
+    apiVersions: ["v1"]
+    resources: ["pods"]
+`
+	commit := CommitInfo{SHA: "0123abc", Author: "Jane Doe <jane@example.com>", Timestamp: time.Date(2018, 1, 1, 0, 0, 0, 0, time.UTC)}
+	got, err := DecodeFromGitBlob([]byte(blob), commit)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.Commit != commit {
+		t.Errorf("expected commit %+v, got %+v", commit, got.Commit)
+	}
+	if got.Config.Name != "example" || len(got.Config.Initializers) != 1 || got.Config.Initializers[0].Name != "a.example.com" {
+		t.Errorf("unexpected config %+v", got.Config)
+	}
+	if want := map[string]string{"owner": "me"}; !reflect.DeepEqual(got.Config.Annotations, want) {
+		t.Errorf("expected annotations %v, got %v", want, got.Config.Annotations)
+	}
+
+	for name, data := range map[string]string{
+		"malformed":  "initializers: {",
+		"wrong kind": "apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: example\n",
+	} {
+		if _, err := DecodeFromGitBlob([]byte(data), commit); err == nil || !strings.Contains(err.Error(), commit.SHA) {
+			t.Errorf("%s: expected an error naming the commit, got %v", name, err)
+		}
+	}
+
+	invalid := strings.Replace(blob, "a.example.com", "unqualified", 1)
+	_, err = DecodeFromGitBlob([]byte(invalid), commit)
+	if err == nil || !strings.Contains(err.Error(), commit.SHA) || !strings.Contains(err.Error(), "initializers[0].name") {
+		t.Errorf("expected a validation error naming the commit and field, got %v", err)
+	}
+}
@@ -0,0 +1,74 @@
+/**
+ * Copyright (C) 2015 Red Hat, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *         http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package initializers
+
+import (
+	"reflect"
+)
+
+// The v1alpha1 API server registered no defaults for InitializerConfiguration.
+// The defaults below are applied by this package only, to complete rules that
+// validation would otherwise reject.
+
+// SetDefaults_InitializerConfiguration applies SetDefaults_Rule to every rule
+// of obj.
+func SetDefaults_InitializerConfiguration(obj *InitializerConfiguration) {
+	for i := range obj.Initializers {
+		for j := range obj.Initializers[i].Rules {
+			SetDefaults_Rule(&obj.Initializers[i].Rules[j])
+		}
+	}
+}
+
+// SetDefaults_Rule selects the core API group when no group is given.
+func SetDefaults_Rule(obj *Rule) {
+	if len(obj.APIGroups) == 0 {
+		obj.APIGroups = []string{""}
+	}
+}
+
+// FieldChange records a field altered by defaulting.
+type FieldChange struct {
+	Path string
+	Old  interface{}
+	New  interface{}
+}
+
+// DefaultingDiff reports the rule fields SetDefaults_InitializerConfiguration
+// would change, without modifying cfg.
+func DefaultingDiff(cfg InitializerConfiguration) []FieldChange {
+	defaulted := cfg.DeepCopy()
+	SetDefaults_InitializerConfiguration(defaulted)
+	var changes []FieldChange
+	for i, initializer := range cfg.Initializers {
+		for j, rule := range initializer.Rules {
+			after := defaulted.Initializers[i].Rules[j]
+			for _, f := range []struct {
+				name       string
+				old, value []string
+			}{
+				{"apiGroups", rule.APIGroups, after.APIGroups},
+				{"apiVersions", rule.APIVersions, after.APIVersions},
+				{"resources", rule.Resources, after.Resources},
+			} {
+				if !reflect.DeepEqual(f.old, f.value) {
+					changes = append(changes, FieldChange{Path: rulePath(i, j).Child(f.name).String(), Old: f.old, New: f.value})
+				}
+			}
+		}
+	}
+	return changes
+}
@@ -0,0 +1,48 @@
+/**
+ * Copyright (C) 2015 Red Hat, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *         http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package initializers
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestDefaultingDiff(t *testing.T) {
+	cfg := InitializerConfiguration{Initializers: []Initializer{{
+		Name: "a.example.com",
+		Rules: []Rule{
+			{APIGroups: []string{"apps"}, APIVersions: []string{"v1"}, Resources: []string{"deployments"}},
+			{APIVersions: []string{"v1"}, Resources: []string{"pods"}},
+		},
+	}}}
+	original := *cfg.DeepCopy()
+	want := []FieldChange{{
+		Path: "initializers[0].rules[1].apiGroups",
+		Old:  []string(nil),
+		New:  []string{""},
+	}}
+	if got := DefaultingDiff(cfg); !reflect.DeepEqual(got, want) {
+		t.Errorf("expected %#v, got %#v", want, got)
+	}
+	if !reflect.DeepEqual(cfg, original) {
+		t.Error("expected cfg not to be modified")
+	}
+
+	SetDefaults_InitializerConfiguration(&cfg)
+	if got := DefaultingDiff(cfg); len(got) != 0 {
+		t.Errorf("expected no changes once defaulted, got %#v", got)
+	}
+}
@@ -0,0 +1,126 @@
+/**
+ * Copyright (C) 2015 Red Hat, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *         http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package initializers
+
+import (
+	"fmt"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/yaml"
+)
+
+const kubectlApplyCommand = "kubectl apply -f -"
+
+// ToRegoData converts cfg into plain JSON compatible values for use as Rego
+// data. Only the name and initializers are kept, and absent slices become
+// empty arrays so policies never need to test for null.
+func ToRegoData(cfg InitializerConfiguration) map[string]interface{} {
+	initializers := make([]interface{}, 0, len(cfg.Initializers))
+	for _, initializer := range cfg.Initializers {
+		rules := make([]interface{}, 0, len(initializer.Rules))
+		for _, rule := range initializer.Rules {
+			rules = append(rules, map[string]interface{}{
+				"apiGroups":   regoStrings(rule.APIGroups),
+				"apiVersions": regoStrings(rule.APIVersions),
+				"resources":   regoStrings(rule.Resources),
+			})
+		}
+		initializers = append(initializers, map[string]interface{}{
+			"name":  initializer.Name,
+			"rules": rules,
+		})
+	}
+	return map[string]interface{}{
+		"name":         cfg.Name,
+		"initializers": initializers,
+	}
+}
+
+func regoStrings(values []string) []interface{} {
+	out := make([]interface{}, len(values))
+	for i, v := range values {
+		out[i] = v
+	}
+	return out
+}
+
+// ToCRDStorage returns the unstructured object a custom resource holding cfg
+// would persist, with the initializers nested under spec.
+func ToCRDStorage(cfg InitializerConfiguration) (map[string]interface{}, error) {
+	cfg.APIVersion = SchemeGroupVersion.String()
+	cfg.Kind = Kind
+	obj, err := runtime.DefaultUnstructuredConverter.ToUnstructured(&cfg)
+	if err != nil {
+		return nil, err
+	}
+	spec := map[string]interface{}{}
+	if initializers, ok := obj["initializers"]; ok {
+		spec["initializers"] = initializers
+		delete(obj, "initializers")
+	}
+	obj["spec"] = spec
+	return obj, nil
+}
+
+// FromCRDStorage reverses ToCRDStorage, rejecting objects of another kind.
+func FromCRDStorage(obj map[string]interface{}) (InitializerConfiguration, error) {
+	var cfg InitializerConfiguration
+	if obj["apiVersion"] != SchemeGroupVersion.String() || obj["kind"] != Kind {
+		return cfg, fmt.Errorf("expected %s %s, got %v %v", SchemeGroupVersion, Kind, obj["apiVersion"], obj["kind"])
+	}
+	flat := make(map[string]interface{}, len(obj))
+	for k, v := range obj {
+		if k != "spec" {
+			flat[k] = v
+		}
+	}
+	if spec, ok := obj["spec"]; ok {
+		specMap, ok := spec.(map[string]interface{})
+		if !ok {
+			return cfg, fmt.Errorf("spec must be an object, got %T", spec)
+		}
+		if initializers, ok := specMap["initializers"]; ok {
+			flat["initializers"] = initializers
+		}
+	}
+	err := runtime.DefaultUnstructuredConverter.FromUnstructured(flat, &cfg)
+	return cfg, err
+}
+
+// ToKubectlApply returns a kubectl command and the manifest to pipe into it to
+// recreate cfg.
+func ToKubectlApply(cfg InitializerConfiguration) (command string, manifest []byte, err error) {
+	manifest, err = canonicalManifest(cfg)
+	if err != nil {
+		return "", nil, err
+	}
+	return kubectlApplyCommand, manifest, nil
+}
+
+// canonicalManifest encodes cfg as YAML with sorted keys, its type meta set
+// and the fields populated by the API server cleared.
+func canonicalManifest(cfg InitializerConfiguration) ([]byte, error) {
+	clean := cfg.DeepCopy()
+	clean.TypeMeta = metav1.TypeMeta{APIVersion: SchemeGroupVersion.String(), Kind: Kind}
+	clean.ObjectMeta = metav1.ObjectMeta{
+		Name:        cfg.Name,
+		Labels:      clean.Labels,
+		Annotations: clean.Annotations,
+		Finalizers:  clean.Finalizers,
+	}
+	return yaml.Marshal(clean)
+}
@@ -0,0 +1,131 @@
+/**
+ * Copyright (C) 2015 Red Hat, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *         http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package initializers
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/equality"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestToRegoData(t *testing.T) {
+	cfg := InitializerConfiguration{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "example",
+			Labels:      map[string]string{"team": "a"},
+			Annotations: map[string]string{"owner": "me"},
+		},
+		Initializers: []Initializer{
+			{Name: "a.example.com", Rules: []Rule{{APIGroups: []string{""}, APIVersions: []string{"v1"}, Resources: []string{"pods"}}}},
+			{Name: "b.example.com"},
+		},
+	}
+	want := map[string]interface{}{
+		"name": "example",
+		"initializers": []interface{}{
+			map[string]interface{}{
+				"name": "a.example.com",
+				"rules": []interface{}{
+					map[string]interface{}{
+						"apiGroups":   []interface{}{""},
+						"apiVersions": []interface{}{"v1"},
+						"resources":   []interface{}{"pods"},
+					},
+				},
+			},
+			map[string]interface{}{
+				"name":  "b.example.com",
+				"rules": []interface{}{},
+			},
+		},
+	}
+	if got := ToRegoData(cfg); !reflect.DeepEqual(got, want) {
+		t.Errorf("expected %#v, got %#v", want, got)
+	}
+}
+
+func TestCRDStorageRoundTrip(t *testing.T) {
+	cfg := InitializerConfiguration{
+		TypeMeta: metav1.TypeMeta{APIVersion: SchemeGroupVersion.String(), Kind: Kind},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:              "example",
+			Labels:            map[string]string{"team": "a"},
+			CreationTimestamp: metav1.NewTime(time.Date(2018, 1, 1, 0, 0, 0, 0, time.UTC)),
+		},
+		Initializers: []Initializer{
+			{Name: "a.example.com", Rules: []Rule{{APIGroups: []string{""}, APIVersions: []string{"v1"}, Resources: []string{"pods"}}}},
+			{Name: "b.example.com"},
+		},
+	}
+	obj, err := ToCRDStorage(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := obj["initializers"]; ok {
+		t.Error("expected initializers to move under spec")
+	}
+	if spec, ok := obj["spec"].(map[string]interface{}); !ok || spec["initializers"] == nil {
+		t.Errorf("expected spec.initializers, got %v", obj["spec"])
+	}
+	got, err := FromCRDStorage(obj)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !equality.Semantic.DeepEqual(got, cfg) {
+		t.Errorf("expected %#v, got %#v", cfg, got)
+	}
+
+	obj["kind"] = "Other"
+	if _, err := FromCRDStorage(obj); err == nil {
+		t.Error("expected an error for another kind")
+	}
+}
+
+func TestToKubectlApply(t *testing.T) {
+	cfg := InitializerConfiguration{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            "example",
+			Labels:          map[string]string{"team": "a"},
+			ResourceVersion: "42",
+			UID:             "0123",
+		},
+		Initializers: []Initializer{
+			{Name: "a.example.com", Rules: []Rule{{APIGroups: []string{""}, APIVersions: []string{"v1"}, Resources: []string{"pods"}}}},
+		},
+	}
+	command, manifest, err := ToKubectlApply(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if command != "kubectl apply -f -" {
+		t.Errorf("unexpected command %q", command)
+	}
+	decoded, err := DecodeWithPositions(manifest)
+	if err != nil {
+		t.Fatalf("decoding %s: %v", manifest, err)
+	}
+	want := InitializerConfiguration{
+		TypeMeta:     metav1.TypeMeta{APIVersion: SchemeGroupVersion.String(), Kind: Kind},
+		ObjectMeta:   metav1.ObjectMeta{Name: "example", Labels: map[string]string{"team": "a"}},
+		Initializers: cfg.Initializers,
+	}
+	if !equality.Semantic.DeepEqual(decoded, want) {
+		t.Errorf("expected %#v, got %#v", want, decoded)
+	}
+}
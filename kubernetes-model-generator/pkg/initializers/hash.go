@@ -0,0 +1,134 @@
+/**
+ * Copyright (C) 2015 Red Hat, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *         http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package initializers
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+// SpecHash returns the hex encoded SHA-256 of the JSON encoded initializers,
+// so configs differing only in metadata hash equally.
+func SpecHash(cfg InitializerConfiguration) string {
+	data, err := json.Marshal(cfg.Initializers)
+	if err != nil {
+		// Initializers only hold strings and slices, which always encode.
+		panic(err)
+	}
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+// ShortID returns the first eight characters of SpecHash, for display.
+func ShortID(cfg InitializerConfiguration) string {
+	return SpecHash(cfg)[:8]
+}
+
+// ChangeToken returns "" when old and new have equivalent canonical specs, and
+// otherwise a token hashed from the changed spec fields and the new spec, so
+// the same change always yields the same token.
+func ChangeToken(old, new InitializerConfiguration) string {
+	oldSpec, newSpec := old.DeepCopy(), new.DeepCopy()
+	Canonicalize(oldSpec)
+	Canonicalize(newSpec)
+	changed := changedSpecFields(oldSpec.Initializers, newSpec.Initializers)
+	if len(changed) == 0 {
+		return ""
+	}
+	sum := sha256.Sum256([]byte(strings.Join(changed, "\n") + "\n" + SpecHash(*newSpec)))
+	return hex.EncodeToString(sum[:8])
+}
+
+func changedSpecFields(old, new []Initializer) []string {
+	var changed []string
+	for i := 0; i < len(old) || i < len(new); i++ {
+		path := initializerPath(i)
+		if i >= len(old) || i >= len(new) {
+			changed = append(changed, path.String())
+			continue
+		}
+		if old[i].Name != new[i].Name {
+			changed = append(changed, path.Child("name").String())
+		}
+		changed = append(changed, changedRuleFields(old[i].Rules, new[i].Rules, path.Child("rules"))...)
+	}
+	return changed
+}
+
+func changedRuleFields(old, new []Rule, path *field.Path) []string {
+	var changed []string
+	for i := 0; i < len(old) || i < len(new); i++ {
+		if i >= len(old) || i >= len(new) {
+			changed = append(changed, path.Index(i).String())
+			continue
+		}
+		if !stringsEqual(old[i].APIGroups, new[i].APIGroups) {
+			changed = append(changed, path.Index(i).Child("apiGroups").String())
+		}
+		if !stringsEqual(old[i].APIVersions, new[i].APIVersions) {
+			changed = append(changed, path.Index(i).Child("apiVersions").String())
+		}
+		if !stringsEqual(old[i].Resources, new[i].Resources) {
+			changed = append(changed, path.Index(i).Child("resources").String())
+		}
+	}
+	return changed
+}
+
+// ContentAddressedName returns the canonical YAML encoding of cfg, with its
+// rules canonicalized, and a file name derived from the SpecHash of the
+// canonicalized initializers, so configs differing only in metadata share it.
+func ContentAddressedName(cfg InitializerConfiguration) (filename string, data []byte, err error) {
+	canonical := cfg.DeepCopy()
+	Canonicalize(canonical)
+	data, err = canonicalManifest(*canonical)
+	if err != nil {
+		return "", nil, err
+	}
+	return SpecHash(*canonical)[:16] + ".yaml", data, nil
+}
+
+// FingerprintOptions tunes Fingerprint.
+type FingerprintOptions struct {
+	// OrgSuffix is stripped from initializer names ending in it. It accepts
+	// the same forms as EnforceOrgSuffix.
+	OrgSuffix string
+	// IgnoreNames leaves initializer names out entirely, so only the rules and
+	// their initializer order are hashed.
+	IgnoreNames bool
+}
+
+// Fingerprint hashes the canonical rules of cfg together with its initializer
+// names stripped of opts.OrgSuffix, so the same logical config deployed to
+// clusters with different org suffixes fingerprints equally.
+func Fingerprint(cfg InitializerConfiguration, opts FingerprintOptions) string {
+	canonical := cfg.DeepCopy()
+	Canonicalize(canonical)
+	for i := range canonical.Initializers {
+		name := &canonical.Initializers[i].Name
+		switch {
+		case opts.IgnoreNames:
+			*name = ""
+		case opts.OrgSuffix != "":
+			*name = strings.TrimSuffix(*name, normalizeOrgSuffix(opts.OrgSuffix))
+		}
+	}
+	return SpecHash(*canonical)
+}
@@ -0,0 +1,160 @@
+/**
+ * Copyright (C) 2015 Red Hat, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *         http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package initializers
+
+import (
+	"regexp"
+	"strings"
+	"testing"
+)
+
+func TestShortID(t *testing.T) {
+	cfg := InitializerConfiguration{Initializers: []Initializer{{
+		Name:  "a.example.com",
+		Rules: []Rule{{APIGroups: []string{""}, APIVersions: []string{"v1"}, Resources: []string{"pods"}}},
+	}}}
+	id := ShortID(cfg)
+	if len(id) != 8 {
+		t.Fatalf("expected 8 characters, got %q", id)
+	}
+
+	renamed := *cfg.DeepCopy()
+	renamed.Name = "other"
+	renamed.Labels = map[string]string{"team": "a"}
+	if got := ShortID(renamed); got != id {
+		t.Errorf("expected metadata changes to keep ID %q, got %q", id, got)
+	}
+
+	changed := *cfg.DeepCopy()
+	changed.Initializers[0].Rules[0].Resources = []string{"services"}
+	if got := ShortID(changed); got == id {
+		t.Errorf("expected a different spec to change ID %q", id)
+	}
+}
+
+func TestChangeToken(t *testing.T) {
+	old := InitializerConfiguration{Initializers: []Initializer{{
+		Name:  "a.example.com",
+		Rules: []Rule{{APIGroups: []string{"apps", ""}, APIVersions: []string{"v1"}, Resources: []string{"pods", "deployments"}}},
+	}}}
+	equivalent := *old.DeepCopy()
+	equivalent.Name = "renamed"
+	equivalent.Initializers[0].Rules[0].APIGroups = []string{"", "apps", "apps"}
+	if token := ChangeToken(old, equivalent); token != "" {
+		t.Errorf("expected no token for equivalent configs, got %q", token)
+	}
+
+	changed := *old.DeepCopy()
+	changed.Initializers[0].Rules[0].Resources = []string{"pods"}
+	token := ChangeToken(old, changed)
+	if token == "" {
+		t.Fatal("expected a token for a changed config")
+	}
+	if again := ChangeToken(*old.DeepCopy(), *changed.DeepCopy()); again != token {
+		t.Errorf("expected the same token %q on repeat, got %q", token, again)
+	}
+	other := *old.DeepCopy()
+	other.Initializers[0].Rules[0].APIVersions = []string{"v1beta1"}
+	if ChangeToken(old, other) == token {
+		t.Errorf("expected a different change to produce another token than %q", token)
+	}
+}
+
+func TestContentAddressedName(t *testing.T) {
+	cfg := InitializerConfiguration{Initializers: []Initializer{{
+		Name:  "a.example.com",
+		Rules: []Rule{{APIGroups: []string{"apps", ""}, APIVersions: []string{"v1"}, Resources: []string{"pods", "*"}}},
+	}}}
+	cfg.Name = "example"
+	name, data, err := ContentAddressedName(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !regexp.MustCompile(`^[0-9a-f]{16}\.yaml$`).MatchString(name) {
+		t.Errorf("unexpected name %q", name)
+	}
+
+	// Server populated fields and rule order do not change the name.
+	same := *cfg.DeepCopy()
+	same.ResourceVersion = "42"
+	same.Initializers[0].Rules[0].APIGroups = []string{"", "apps"}
+	sameName, sameData, err := ContentAddressedName(same)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if sameName != name || string(sameData) != string(data) {
+		t.Errorf("expected %s, got %s", name, sameName)
+	}
+
+	// Only the spec names the file; metadata is still written out.
+	renamed := *cfg.DeepCopy()
+	renamed.Name = "renamed"
+	renamed.Labels = map[string]string{"team": "a"}
+	renamedName, renamedData, err := ContentAddressedName(renamed)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if renamedName != name {
+		t.Errorf("expected the same spec under another name to keep %s, got %s", name, renamedName)
+	}
+	if !strings.Contains(string(renamedData), "name: renamed") {
+		t.Errorf("expected the manifest to keep its metadata, got %s", renamedData)
+	}
+
+	different := *cfg.DeepCopy()
+	different.Initializers[0].Rules[0].APIVersions = []string{"v1beta1"}
+	differentName, _, err := ContentAddressedName(different)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if differentName == name {
+		t.Errorf("expected a different spec to change name %s", name)
+	}
+}
+
+func TestFingerprint(t *testing.T) {
+	rules := []Rule{{APIGroups: []string{"apps", ""}, APIVersions: []string{"v1"}, Resources: []string{"pods", "deployments"}}}
+	east := testConfig("east",
+		Initializer{Name: "podimage.east.example.com", Rules: rules},
+		Initializer{Name: "quota.east.example.com", Rules: rules},
+	)
+	west := testConfig("west",
+		Initializer{Name: "podimage.west.example.com", Rules: []Rule{{APIGroups: []string{"", "apps"}, APIVersions: []string{"v1"}, Resources: []string{"pods", "deployments"}}}},
+		Initializer{Name: "quota.west.example.com", Rules: rules},
+	)
+	if a, b := Fingerprint(east, FingerprintOptions{OrgSuffix: "east.example.com"}), Fingerprint(west, FingerprintOptions{OrgSuffix: "*.west.example.com"}); a != b {
+		t.Errorf("expected the same logical config to fingerprint equally, got %s and %s", a, b)
+	}
+
+	// Only the org suffix is stripped, so other name segments still count.
+	team1 := testConfig("", Initializer{Name: "foo.team1.example.com", Rules: rules})
+	team2 := testConfig("", Initializer{Name: "foo.team2.other.io", Rules: rules})
+	opts := FingerprintOptions{OrgSuffix: "example.com"}
+	if Fingerprint(team1, opts) == Fingerprint(team2, opts) {
+		t.Error("expected different initializer names to fingerprint differently")
+	}
+	if Fingerprint(team1, FingerprintOptions{IgnoreNames: true}) != Fingerprint(team2, FingerprintOptions{IgnoreNames: true}) {
+		t.Error("expected names to be ignored")
+	}
+
+	changed := testConfig("east",
+		Initializer{Name: "podimage.east.example.com", Rules: rules},
+		Initializer{Name: "quota.east.example.com", Rules: []Rule{{APIGroups: []string{"apps", ""}, APIVersions: []string{"v1"}, Resources: []string{"pods"}}}},
+	)
+	if Fingerprint(east, FingerprintOptions{}) == Fingerprint(changed, FingerprintOptions{}) {
+		t.Error("expected different rules to fingerprint differently")
+	}
+}
@@ -0,0 +1,114 @@
+/**
+ * Copyright (C) 2015 Red Hat, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *         http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package initializers
+
+import (
+	"sort"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// OldestConfig returns the item with the earliest creation timestamp, breaking
+// ties by name. Items without a timestamp are ignored.
+func OldestConfig(list InitializerConfigurationList) (*InitializerConfiguration, bool) {
+	var oldest *InitializerConfiguration
+	for i := range list.Items {
+		item := &list.Items[i]
+		if item.CreationTimestamp.IsZero() {
+			continue
+		}
+		if oldest == nil || item.CreationTimestamp.Before(&oldest.CreationTimestamp) ||
+			item.CreationTimestamp.Equal(&oldest.CreationTimestamp) && item.Name < oldest.Name {
+			oldest = item
+		}
+	}
+	return oldest, oldest != nil
+}
+
+// FindDuplicateSpecs groups item names by SpecHash, keeping only the hashes
+// shared by more than one item.
+func FindDuplicateSpecs(list InitializerConfigurationList) map[string][]string {
+	byHash := make(map[string][]string)
+	for _, item := range list.Items {
+		hash := SpecHash(item)
+		byHash[hash] = append(byHash[hash], item.Name)
+	}
+	for hash, names := range byHash {
+		if len(names) < 2 {
+			delete(byHash, hash)
+			continue
+		}
+		sort.Strings(names)
+	}
+	return byHash
+}
+
+// EquivalenceClasses groups item names by SpecHash, singletons included. Each
+// group is sorted and the groups are ordered by their first name.
+func EquivalenceClasses(list InitializerConfigurationList) [][]string {
+	byHash := make(map[string][]string)
+	for _, item := range list.Items {
+		hash := SpecHash(item)
+		byHash[hash] = append(byHash[hash], item.Name)
+	}
+	classes := make([][]string, 0, len(byHash))
+	for _, names := range byHash {
+		sort.Strings(names)
+		classes = append(classes, names)
+	}
+	sort.Slice(classes, func(i, j int) bool { return classes[i][0] < classes[j][0] })
+	return classes
+}
+
+// AffectedByDeprecation returns, in list order, the names of the items with a
+// rule matching deprecated, directly or through wildcards.
+func AffectedByDeprecation(list InitializerConfigurationList, deprecated schema.GroupVersionResource) []string {
+	var names []string
+	for _, item := range list.Items {
+		if configMatches(item, deprecated) {
+			names = append(names, item.Name)
+		}
+	}
+	return names
+}
+
+// FindDeadConfigs returns, in list order, the names of the items that can never
+// intercept anything: none of their rules has a wildcard resource or a
+// resource served by a matching group/version. Items without rules count as
+// dead.
+func FindDeadConfigs(list InitializerConfigurationList, served map[schema.GroupVersion][]string) []string {
+	var names []string
+	for _, item := range list.Items {
+		if !configLive(item, served) {
+			names = append(names, item.Name)
+		}
+	}
+	return names
+}
+
+func configLive(cfg InitializerConfiguration, served map[schema.GroupVersion][]string) bool {
+	for _, initializer := range cfg.Initializers {
+		for _, rule := range initializer.Rules {
+			for _, resource := range rule.Resources {
+				if strings.Contains(resource, wildcard) || ruleServes(rule, resource, served) {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
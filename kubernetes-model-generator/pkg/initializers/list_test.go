@@ -0,0 +1,121 @@
+/**
+ * Copyright (C) 2015 Red Hat, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *         http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package initializers
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+func TestOldestConfig(t *testing.T) {
+	base := time.Date(2018, 1, 1, 0, 0, 0, 0, time.UTC)
+	list := InitializerConfigurationList{Items: []InitializerConfiguration{
+		{ObjectMeta: metav1.ObjectMeta{Name: "newest", CreationTimestamp: metav1.NewTime(base.Add(2 * time.Hour))}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "untimed"}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "b-oldest", CreationTimestamp: metav1.NewTime(base)}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "a-oldest", CreationTimestamp: metav1.NewTime(base)}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "middle", CreationTimestamp: metav1.NewTime(base.Add(time.Hour))}},
+	}}
+	oldest, ok := OldestConfig(list)
+	if !ok {
+		t.Fatal("expected an oldest config")
+	}
+	if oldest.Name != "a-oldest" {
+		t.Errorf("expected a-oldest, got %s", oldest.Name)
+	}
+
+	untimed := InitializerConfigurationList{Items: []InitializerConfiguration{
+		testConfig("a"),
+		testConfig("b"),
+	}}
+	if oldest, ok := OldestConfig(untimed); ok || oldest != nil {
+		t.Errorf("expected no oldest config, got %v", oldest)
+	}
+}
+
+func TestFindDuplicateSpecs(t *testing.T) {
+	pods := Initializer{Name: "a.example.com", Rules: []Rule{{APIGroups: []string{""}, APIVersions: []string{"v1"}, Resources: []string{"pods"}}}}
+	services := Initializer{Name: "a.example.com", Rules: []Rule{{APIGroups: []string{""}, APIVersions: []string{"v1"}, Resources: []string{"services"}}}}
+	first := testConfig("first", pods)
+	first.Labels = map[string]string{"copy": "1"}
+	list := InitializerConfigurationList{Items: []InitializerConfiguration{
+		testConfig("second", pods),
+		testConfig("unique", services),
+		first,
+	}}
+	want := map[string][]string{SpecHash(first): {"first", "second"}}
+	if got := FindDuplicateSpecs(list); !reflect.DeepEqual(got, want) {
+		t.Errorf("expected %v, got %v", want, got)
+	}
+}
+
+func TestAffectedByDeprecation(t *testing.T) {
+	deprecated := schema.GroupVersionResource{Group: "extensions", Version: "v1beta1", Resource: "deployments"}
+	list := InitializerConfigurationList{Items: []InitializerConfiguration{
+		testConfig("wildcard", Initializer{Name: "a.example.com", Rules: []Rule{{APIGroups: []string{"*"}, APIVersions: []string{"v1beta1"}, Resources: []string{"*"}}}}),
+		testConfig("unaffected", Initializer{Name: "a.example.com", Rules: []Rule{{APIGroups: []string{"apps"}, APIVersions: []string{"v1"}, Resources: []string{"deployments"}}}}),
+		testConfig("direct", Initializer{Name: "a.example.com", Rules: []Rule{{APIGroups: []string{"extensions"}, APIVersions: []string{"v1beta1"}, Resources: []string{"deployments"}}}}),
+		testConfig("subresource", Initializer{Name: "a.example.com", Rules: []Rule{{APIGroups: []string{"extensions"}, APIVersions: []string{"v1beta1"}, Resources: []string{"deployments/scale"}}}}),
+	}}
+	want := []string{"wildcard", "direct"}
+	if got := AffectedByDeprecation(list, deprecated); !reflect.DeepEqual(got, want) {
+		t.Errorf("expected %v, got %v", want, got)
+	}
+}
+
+func TestEquivalenceClasses(t *testing.T) {
+	pods := Initializer{Name: "a.example.com", Rules: []Rule{{APIGroups: []string{""}, APIVersions: []string{"v1"}, Resources: []string{"pods"}}}}
+	secrets := Initializer{Name: "a.example.com", Rules: []Rule{{APIGroups: []string{""}, APIVersions: []string{"v1"}, Resources: []string{"secrets"}}}}
+	services := Initializer{Name: "a.example.com", Rules: []Rule{{APIGroups: []string{""}, APIVersions: []string{"v1"}, Resources: []string{"services"}}}}
+	list := InitializerConfigurationList{Items: []InitializerConfiguration{
+		testConfig("zeta", pods),
+		testConfig("lone", secrets),
+		testConfig("mu", services),
+		testConfig("alpha", pods),
+		testConfig("beta", services),
+	}}
+	want := [][]string{{"alpha", "zeta"}, {"beta", "mu"}, {"lone"}}
+	if got := EquivalenceClasses(list); !reflect.DeepEqual(got, want) {
+		t.Errorf("expected %v, got %v", want, got)
+	}
+}
+
+func TestFindDeadConfigs(t *testing.T) {
+	served := map[schema.GroupVersion][]string{
+		{Group: "", Version: "v1"}:     {"pods", "services"},
+		{Group: "apps", Version: "v1"}: {"deployments"},
+	}
+	list := InitializerConfigurationList{Items: []InitializerConfiguration{
+		testConfig("live", Initializer{Name: "a.example.com", Rules: []Rule{
+			{APIGroups: []string{"tpr.example.com"}, APIVersions: []string{"v1"}, Resources: []string{"widgets"}},
+			{APIGroups: []string{"apps"}, APIVersions: []string{"*"}, Resources: []string{"deployments"}},
+		}}),
+		testConfig("dead", Initializer{Name: "a.example.com", Rules: []Rule{
+			{APIGroups: []string{"extensions"}, APIVersions: []string{"v1beta1"}, Resources: []string{"deployments"}},
+			{APIGroups: []string{""}, APIVersions: []string{"v1"}, Resources: []string{"widgets"}},
+		}}),
+		testConfig("wildcard", Initializer{Name: "a.example.com", Rules: []Rule{{APIGroups: []string{"unserved.example.com"}, APIVersions: []string{"v1"}, Resources: []string{"*"}}}}),
+		testConfig("no rules", Initializer{Name: "a.example.com"}),
+	}}
+	want := []string{"dead", "no rules"}
+	if got := FindDeadConfigs(list, served); !reflect.DeepEqual(got, want) {
+		t.Errorf("expected %v, got %v", want, got)
+	}
+}
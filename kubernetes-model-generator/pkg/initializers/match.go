@@ -0,0 +1,168 @@
+/**
+ * Copyright (C) 2015 Red Hat, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *         http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package initializers
+
+import (
+	"fmt"
+	"math/big"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// RuleMatches reports whether r intercepts gvr. The resource of gvr may name a
+// subresource as resource/subresource.
+func RuleMatches(r Rule, gvr schema.GroupVersionResource) bool {
+	if !matchesValue(r.APIGroups, gvr.Group) || !matchesValue(r.APIVersions, gvr.Version) {
+		return false
+	}
+	for _, pattern := range r.Resources {
+		if resourceMatches(pattern, gvr.Resource) {
+			return true
+		}
+	}
+	return false
+}
+
+// resourceMatches applies the Rule.Resources wildcard semantics: "*" matches
+// all resources but no subresources, "pods/*" all subresources of pods,
+// "*/scale" every scale subresource and "*/*" everything.
+func resourceMatches(pattern, resource string) bool {
+	if pattern == allWithSubresources {
+		return true
+	}
+	pRes, pSub := splitResource(pattern)
+	res, sub := splitResource(resource)
+	if pRes != wildcard && pRes != res {
+		return false
+	}
+	if pSub == "" {
+		return sub == ""
+	}
+	return sub != "" && (pSub == wildcard || pSub == sub)
+}
+
+func splitResource(resource string) (string, string) {
+	if i := strings.Index(resource, "/"); i >= 0 {
+		return resource[:i], resource[i+1:]
+	}
+	return resource, ""
+}
+
+func configMatches(cfg InitializerConfiguration, gvr schema.GroupVersionResource) bool {
+	for _, initializer := range cfg.Initializers {
+		for _, rule := range initializer.Rules {
+			if RuleMatches(rule, gvr) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// ImpactRadius counts the distinct GVRs in allGVRs that cfg intercepts.
+func ImpactRadius(cfg InitializerConfiguration, allGVRs []schema.GroupVersionResource) int {
+	matched := make(map[schema.GroupVersionResource]bool)
+	for _, gvr := range allGVRs {
+		if configMatches(cfg, gvr) {
+			matched[gvr] = true
+		}
+	}
+	return len(matched)
+}
+
+// ReachPercentage returns the share, from 0 to 100, of the distinct GVRs in
+// allGVRs that cfg intercepts. An empty universe has no reach.
+func ReachPercentage(cfg InitializerConfiguration, allGVRs []schema.GroupVersionResource) float64 {
+	universe := make(map[schema.GroupVersionResource]bool, len(allGVRs))
+	for _, gvr := range allGVRs {
+		universe[gvr] = true
+	}
+	if len(universe) == 0 {
+		return 0
+	}
+	return 100 * float64(ImpactRadius(cfg, allGVRs)) / float64(len(universe))
+}
+
+// CountMatches counts the objects cfg would intercept. Object kinds are mapped
+// to resources with the conventional lowercase plural, as no REST mapper is
+// available.
+func CountMatches(cfg InitializerConfiguration, objs []unstructured.Unstructured) int {
+	count := 0
+	for i := range objs {
+		gvr, _ := meta.UnsafeGuessKindToResource(objs[i].GroupVersionKind())
+		if configMatches(cfg, gvr) {
+			count++
+		}
+	}
+	return count
+}
+
+// ExplainMatch describes, one line per matching rule, why cfg intercepts gvr,
+// for example "initializer a.example.com rule 0 matched via wildcard apiGroups,
+// exact apiVersions, exact resources".
+func ExplainMatch(cfg InitializerConfiguration, gvr schema.GroupVersionResource) []string {
+	var lines []string
+	for _, initializer := range cfg.Initializers {
+		for i, rule := range initializer.Rules {
+			if !RuleMatches(rule, gvr) {
+				continue
+			}
+			reasons := []string{
+				matchReason("apiGroups", rule.APIGroups, gvr.Group),
+				matchReason("apiVersions", rule.APIVersions, gvr.Version),
+				resourceMatchReason(rule.Resources, gvr.Resource),
+			}
+			lines = append(lines, fmt.Sprintf("initializer %s rule %d matched via %s", initializer.Name, i, strings.Join(reasons, ", ")))
+		}
+	}
+	return lines
+}
+
+func matchReason(fieldName string, values []string, value string) string {
+	if contains(values, value) {
+		return "exact " + fieldName
+	}
+	return "wildcard " + fieldName
+}
+
+// resourceMatchReason prefers an exact match over a wildcard one and names the
+// wildcard pattern used otherwise.
+func resourceMatchReason(patterns []string, resource string) string {
+	if contains(patterns, resource) {
+		return "exact resources"
+	}
+	for _, pattern := range patterns {
+		if resourceMatches(pattern, resource) {
+			return fmt.Sprintf("wildcard resources %q", pattern)
+		}
+	}
+	return ""
+}
+
+// RuleBitset sets bit gvrIndex[gvr] for every indexed GVR that r matches, so
+// the overlap of two rules over the same index is the AND of their bitsets.
+func RuleBitset(r Rule, gvrIndex map[schema.GroupVersionResource]int) *big.Int {
+	bits := new(big.Int)
+	for gvr, i := range gvrIndex {
+		if RuleMatches(r, gvr) {
+			bits.SetBit(bits, i, 1)
+		}
+	}
+	return bits
+}
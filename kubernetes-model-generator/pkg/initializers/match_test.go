@@ -0,0 +1,185 @@
+/**
+ * Copyright (C) 2015 Red Hat, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *         http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package initializers
+
+import (
+	"math/big"
+	"math/bits"
+	"reflect"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+var testUniverse = []schema.GroupVersionResource{
+	{Group: "", Version: "v1", Resource: "pods"},
+	{Group: "", Version: "v1", Resource: "services"},
+	{Group: "apps", Version: "v1", Resource: "deployments"},
+	{Group: "apps", Version: "v1", Resource: "deployments/scale"},
+	{Group: "apps", Version: "v1beta1", Resource: "deployments"},
+	{Group: "batch", Version: "v1", Resource: "jobs"},
+}
+
+func TestImpactRadius(t *testing.T) {
+	cfg := InitializerConfiguration{Initializers: []Initializer{
+		{Name: "a.example.com", Rules: []Rule{
+			{APIGroups: []string{"apps"}, APIVersions: []string{"*"}, Resources: []string{"*"}},
+		}},
+		{Name: "b.example.com", Rules: []Rule{
+			{APIGroups: []string{""}, APIVersions: []string{"v1"}, Resources: []string{"pods"}},
+			{APIGroups: []string{"apps"}, APIVersions: []string{"v1"}, Resources: []string{"deployments"}},
+		}},
+	}}
+	// The apps wildcard covers both deployments versions but not the scale
+	// subresource, and the repeated pods entry counts once.
+	universe := append(append([]schema.GroupVersionResource{}, testUniverse...), testUniverse[0])
+	if got := ImpactRadius(cfg, universe); got != 3 {
+		t.Errorf("expected 3, got %d", got)
+	}
+}
+
+func unstructuredObject(apiVersion, kind string) unstructured.Unstructured {
+	return unstructured.Unstructured{Object: map[string]interface{}{"apiVersion": apiVersion, "kind": kind}}
+}
+
+func TestCountMatches(t *testing.T) {
+	cfg := InitializerConfiguration{Initializers: []Initializer{{
+		Name: "a.example.com",
+		Rules: []Rule{
+			{APIGroups: []string{""}, APIVersions: []string{"v1"}, Resources: []string{"pods"}},
+			{APIGroups: []string{"apps"}, APIVersions: []string{"*"}, Resources: []string{"deployments"}},
+		},
+	}}}
+	objs := []unstructured.Unstructured{
+		unstructuredObject("v1", "Pod"),
+		unstructuredObject("v1", "Pod"),
+		unstructuredObject("v1", "Service"),
+		unstructuredObject("apps/v1", "Deployment"),
+		unstructuredObject("apps/v1beta2", "Deployment"),
+		unstructuredObject("apps/v1", "StatefulSet"),
+		unstructuredObject("batch/v1", "Job"),
+	}
+	if got := CountMatches(cfg, objs); got != 4 {
+		t.Errorf("expected 4, got %d", got)
+	}
+}
+
+func TestExplainMatch(t *testing.T) {
+	cfg := InitializerConfiguration{Initializers: []Initializer{
+		{Name: "a.example.com", Rules: []Rule{
+			{APIGroups: []string{"batch"}, APIVersions: []string{"v1"}, Resources: []string{"jobs"}},
+			{APIGroups: []string{"*"}, APIVersions: []string{"v1"}, Resources: []string{"*/*"}},
+		}},
+		{Name: "b.example.com", Rules: []Rule{
+			{APIGroups: []string{"apps"}, APIVersions: []string{"v1"}, Resources: []string{"deployments/scale"}},
+		}},
+	}}
+	got := ExplainMatch(cfg, schema.GroupVersionResource{Group: "apps", Version: "v1", Resource: "deployments/scale"})
+	want := []string{
+		`initializer a.example.com rule 1 matched via wildcard apiGroups, exact apiVersions, wildcard resources "*/*"`,
+		"initializer b.example.com rule 0 matched via exact apiGroups, exact apiVersions, exact resources",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("expected %q, got %q", want, got)
+	}
+	if got := ExplainMatch(cfg, schema.GroupVersionResource{Group: "apps", Version: "v1beta1", Resource: "deployments"}); len(got) != 0 {
+		t.Errorf("expected no explanation, got %q", got)
+	}
+}
+
+var overlapRules = [2]Rule{
+	{APIGroups: []string{"*"}, APIVersions: []string{"v1"}, Resources: []string{"*"}},
+	{APIGroups: []string{"", "apps"}, APIVersions: []string{"*"}, Resources: []string{"pods", "deployments", "deployments/scale"}},
+}
+
+func testGVRIndex() map[schema.GroupVersionResource]int {
+	index := make(map[schema.GroupVersionResource]int, len(testUniverse))
+	for i, gvr := range testUniverse {
+		index[gvr] = i
+	}
+	return index
+}
+
+func naiveOverlap(a, b Rule, gvrIndex map[schema.GroupVersionResource]int) int {
+	n := 0
+	for gvr := range gvrIndex {
+		if RuleMatches(a, gvr) && RuleMatches(b, gvr) {
+			n++
+		}
+	}
+	return n
+}
+
+func popcount(x *big.Int) int {
+	n := 0
+	for _, word := range x.Bits() {
+		n += bits.OnesCount(uint(word))
+	}
+	return n
+}
+
+func TestRuleBitsetOverlap(t *testing.T) {
+	index := testGVRIndex()
+	a, b := RuleBitset(overlapRules[0], index), RuleBitset(overlapRules[1], index)
+	overlap := popcount(new(big.Int).And(a, b))
+	if want := naiveOverlap(overlapRules[0], overlapRules[1], index); overlap != want {
+		t.Errorf("expected %d overlapping GVRs, got %d", want, overlap)
+	}
+	// pods and apps/v1 deployments.
+	if overlap != 2 {
+		t.Errorf("expected 2 overlapping GVRs, got %d", overlap)
+	}
+	for gvr, i := range index {
+		if got, want := a.Bit(i) == 1, RuleMatches(overlapRules[0], gvr); got != want {
+			t.Errorf("bit %d for %v: expected %v, got %v", i, gvr, want, got)
+		}
+	}
+}
+
+func BenchmarkRuleBitsetOverlap(b *testing.B) {
+	index := testGVRIndex()
+	x, y := RuleBitset(overlapRules[0], index), RuleBitset(overlapRules[1], index)
+	overlap := new(big.Int)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		popcount(overlap.And(x, y))
+	}
+}
+
+func BenchmarkNaiveOverlap(b *testing.B) {
+	index := testGVRIndex()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		naiveOverlap(overlapRules[0], overlapRules[1], index)
+	}
+}
+
+func TestReachPercentage(t *testing.T) {
+	cfg := InitializerConfiguration{Initializers: []Initializer{{
+		Name: "a.example.com",
+		Rules: []Rule{
+			{APIGroups: []string{"apps"}, APIVersions: []string{"*"}, Resources: []string{"*/*"}},
+		},
+	}}}
+	// deployments in two versions and deployments/scale, out of six GVRs.
+	if got := ReachPercentage(cfg, testUniverse); got != 50 {
+		t.Errorf("expected 50, got %v", got)
+	}
+	if got := ReachPercentage(cfg, nil); got != 0 {
+		t.Errorf("expected 0 for an empty universe, got %v", got)
+	}
+}
@@ -0,0 +1,82 @@
+/**
+ * Copyright (C) 2015 Red Hat, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *         http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package initializers
+
+import (
+	"time"
+)
+
+// SchemaVersionAnnotation records the version of the tooling schema a config
+// was written for.
+const SchemaVersionAnnotation = "initializers.fabric8.io/schema-version"
+
+// SetLabelsExactly replaces the labels of cfg with a copy of desired and
+// reports whether they differed. A nil or empty desired clears the labels.
+func SetLabelsExactly(cfg *InitializerConfiguration, desired map[string]string) (changed bool) {
+	if stringMapsEqual(cfg.Labels, desired) {
+		return false
+	}
+	if len(desired) == 0 {
+		cfg.Labels = nil
+		return true
+	}
+	labels := make(map[string]string, len(desired))
+	for k, v := range desired {
+		labels[k] = v
+	}
+	cfg.Labels = labels
+	return true
+}
+
+func stringMapsEqual(a, b map[string]string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for k, v := range a {
+		if w, ok := b[k]; !ok || w != v {
+			return false
+		}
+	}
+	return true
+}
+
+// SetSchemaVersion records v in the SchemaVersionAnnotation of cfg.
+func SetSchemaVersion(cfg *InitializerConfiguration, v string) {
+	if cfg.Annotations == nil {
+		cfg.Annotations = make(map[string]string)
+	}
+	cfg.Annotations[SchemaVersionAnnotation] = v
+}
+
+// GetSchemaVersion returns the SchemaVersionAnnotation of cfg, if set.
+func GetSchemaVersion(cfg InitializerConfiguration) (string, bool) {
+	v, ok := cfg.Annotations[SchemaVersionAnnotation]
+	return v, ok
+}
+
+// LastModifiedBy returns the manager of the most recently timestamped managed
+// fields entry. Entries without a timestamp are ignored.
+func LastModifiedBy(cfg InitializerConfiguration) (manager string, at time.Time, ok bool) {
+	for _, entry := range cfg.ManagedFields {
+		if entry.Time == nil {
+			continue
+		}
+		if !ok || entry.Time.Time.After(at) {
+			manager, at, ok = entry.Manager, entry.Time.Time, true
+		}
+	}
+	return manager, at, ok
+}
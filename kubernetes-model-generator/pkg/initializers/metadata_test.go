@@ -0,0 +1,119 @@
+/**
+ * Copyright (C) 2015 Red Hat, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *         http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package initializers
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestSetLabelsExactly(t *testing.T) {
+	tests := []struct {
+		name        string
+		labels      map[string]string
+		desired     map[string]string
+		want        map[string]string
+		wantChanged bool
+	}{
+		{
+			name:        "add",
+			labels:      map[string]string{"team": "a"},
+			desired:     map[string]string{"team": "a", "env": "prod"},
+			want:        map[string]string{"team": "a", "env": "prod"},
+			wantChanged: true,
+		},
+		{
+			name:        "remove",
+			labels:      map[string]string{"team": "a", "env": "prod"},
+			desired:     map[string]string{"team": "a"},
+			want:        map[string]string{"team": "a"},
+			wantChanged: true,
+		},
+		{
+			name:    "no change",
+			labels:  map[string]string{"team": "a"},
+			desired: map[string]string{"team": "a"},
+			want:    map[string]string{"team": "a"},
+		},
+		{
+			name:        "nil desired",
+			labels:      map[string]string{"team": "a"},
+			want:        nil,
+			wantChanged: true,
+		},
+		{
+			name: "nil desired without labels",
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			cfg := &InitializerConfiguration{}
+			cfg.Labels = tc.labels
+			if changed := SetLabelsExactly(cfg, tc.desired); changed != tc.wantChanged {
+				t.Errorf("expected changed %v, got %v", tc.wantChanged, changed)
+			}
+			if !reflect.DeepEqual(cfg.Labels, tc.want) {
+				t.Errorf("expected labels %v, got %v", tc.want, cfg.Labels)
+			}
+			if tc.desired != nil {
+				tc.desired["mutated"] = "later"
+				if _, ok := cfg.Labels["mutated"]; ok {
+					t.Error("expected the labels not to alias desired")
+				}
+			}
+		})
+	}
+}
+
+func TestSchemaVersion(t *testing.T) {
+	var cfg InitializerConfiguration
+	if v, ok := GetSchemaVersion(cfg); ok || v != "" {
+		t.Errorf("expected no schema version, got %q", v)
+	}
+	SetSchemaVersion(&cfg, "v2")
+	if v, ok := GetSchemaVersion(cfg); !ok || v != "v2" {
+		t.Errorf("expected schema version v2, got %q, %v", v, ok)
+	}
+	if got := cfg.Annotations[SchemaVersionAnnotation]; got != "v2" {
+		t.Errorf("expected annotation %s=v2, got %q", SchemaVersionAnnotation, got)
+	}
+}
+
+func TestLastModifiedBy(t *testing.T) {
+	base := time.Date(2018, 1, 1, 0, 0, 0, 0, time.UTC)
+	at := func(d time.Duration) *metav1.Time {
+		ts := metav1.NewTime(base.Add(d))
+		return &ts
+	}
+	var cfg InitializerConfiguration
+	cfg.ManagedFields = []metav1.ManagedFieldsEntry{
+		{Manager: "kubectl", Time: at(time.Hour)},
+		{Manager: "operator", Time: at(3 * time.Hour)},
+		{Manager: "untimed"},
+		{Manager: "controller", Time: at(2 * time.Hour)},
+	}
+	manager, when, ok := LastModifiedBy(cfg)
+	if !ok || manager != "operator" || !when.Equal(base.Add(3*time.Hour)) {
+		t.Errorf("expected operator at %v, got %q at %v (%v)", base.Add(3*time.Hour), manager, when, ok)
+	}
+
+	if manager, _, ok := LastModifiedBy(InitializerConfiguration{}); ok || manager != "" {
+		t.Errorf("expected no manager, got %q", manager)
+	}
+}
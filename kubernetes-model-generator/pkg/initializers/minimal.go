@@ -0,0 +1,100 @@
+/**
+ * Copyright (C) 2015 Red Hat, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *         http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package initializers
+
+import (
+	"sort"
+	"strings"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// MinimalRules returns rules covering exactly the target GVRs. Versions of a
+// group serving the same resources share a rule, as do groups whose rules then
+// have the same versions and resources. No wildcards are used, since nothing
+// outside target may be covered.
+func MinimalRules(target []schema.GroupVersionResource) []Rule {
+	resourcesByGV := make(map[schema.GroupVersion]map[string]bool)
+	for _, gvr := range target {
+		gv := gvr.GroupVersion()
+		if resourcesByGV[gv] == nil {
+			resourcesByGV[gv] = make(map[string]bool)
+		}
+		resourcesByGV[gv][gvr.Resource] = true
+	}
+
+	// Per group, versions keyed by their resource set.
+	versionsByGroup := make(map[string]map[string][]string)
+	for gv, resources := range resourcesByGV {
+		key := strings.Join(sortedKeys(resources), ",")
+		if versionsByGroup[gv.Group] == nil {
+			versionsByGroup[gv.Group] = make(map[string][]string)
+		}
+		versionsByGroup[gv.Group][key] = append(versionsByGroup[gv.Group][key], gv.Version)
+	}
+
+	// Groups keyed by their versions and resource set.
+	type shape struct{ versions, resources string }
+	groupsByShape := make(map[shape][]string)
+	for group, versionsByResources := range versionsByGroup {
+		for resources, versions := range versionsByResources {
+			sort.Strings(versions)
+			s := shape{versions: strings.Join(versions, ","), resources: resources}
+			groupsByShape[s] = append(groupsByShape[s], group)
+		}
+	}
+
+	rules := make([]Rule, 0, len(groupsByShape))
+	for s, groups := range groupsByShape {
+		sort.Strings(groups)
+		rules = append(rules, Rule{
+			APIGroups:   groups,
+			APIVersions: strings.Split(s.versions, ","),
+			Resources:   strings.Split(s.resources, ","),
+		})
+	}
+	sort.Slice(rules, func(i, j int) bool {
+		return ruleSortKey(rules[i]) < ruleSortKey(rules[j])
+	})
+	return rules
+}
+
+// MinimalConfigSet returns a config named after initializerName with a single
+// initializer whose MinimalRules cover exactly target.
+func MinimalConfigSet(target []schema.GroupVersionResource, initializerName string) InitializerConfiguration {
+	return InitializerConfiguration{
+		TypeMeta:   metav1.TypeMeta{APIVersion: SchemeGroupVersion.String(), Kind: Kind},
+		ObjectMeta: metav1.ObjectMeta{Name: initializerName},
+		Initializers: []Initializer{{
+			Name:  initializerName,
+			Rules: MinimalRules(target),
+		}},
+	}
+}
+
+func ruleSortKey(r Rule) string {
+	return strings.Join(r.APIGroups, ",") + "/" + strings.Join(r.APIVersions, ",") + "/" + strings.Join(r.Resources, ",")
+}
+
+func sortedKeys(set map[string]bool) []string {
+	keys := make([]string, 0, len(set))
+	for k := range set {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,57 @@
+/**
+ * Copyright (C) 2015 Red Hat, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *         http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package initializers
+
+import (
+	"testing"
+
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+func TestMinimalConfigSet(t *testing.T) {
+	target := []schema.GroupVersionResource{
+		{Group: "", Version: "v1", Resource: "pods"},
+		{Group: "apps", Version: "v1", Resource: "deployments"},
+		{Group: "apps", Version: "v1beta1", Resource: "deployments"},
+		{Group: "extensions", Version: "v1beta1", Resource: "deployments"},
+	}
+	extra := []schema.GroupVersionResource{
+		{Group: "", Version: "v1", Resource: "services"},
+		{Group: "apps", Version: "v1", Resource: "statefulsets"},
+		{Group: "apps", Version: "v1beta2", Resource: "deployments"},
+		{Group: "extensions", Version: "v1beta1", Resource: "ingresses"},
+		{Group: "", Version: "v1", Resource: "pods/status"},
+	}
+	cfg := MinimalConfigSet(target, "minimal.example.com")
+	if cfg.Name != "minimal.example.com" || len(cfg.Initializers) != 1 || cfg.Initializers[0].Name != "minimal.example.com" {
+		t.Fatalf("unexpected config %+v", cfg)
+	}
+	if errs := ValidateInitializerConfiguration(cfg); len(errs) != 0 {
+		t.Errorf("unexpected validation errors %v", errs)
+	}
+	universe := append(append([]schema.GroupVersionResource{}, target...), extra...)
+	if got := ImpactRadius(cfg, universe); got != len(target) {
+		t.Errorf("expected to cover exactly %d GVRs, covered %d", len(target), got)
+	}
+	for _, gvr := range target {
+		if !configMatches(cfg, gvr) {
+			t.Errorf("expected %v to be covered", gvr)
+		}
+	}
+	if n := len(cfg.Initializers[0].Rules); n != 3 {
+		t.Errorf("expected 3 rules, got %d: %+v", n, cfg.Initializers[0].Rules)
+	}
+}
@@ -0,0 +1,70 @@
+/**
+ * Copyright (C) 2015 Red Hat, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *         http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package initializers
+
+import (
+	"regexp"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/util/validation"
+)
+
+const suggestedNameSuffix = "-init"
+
+var nonDNSLabelChars = regexp.MustCompile(`[^a-z0-9]+`)
+
+// SuggestName derives a DNS-1123 subdomain name such as apps-deployments-init
+// from the sorted API groups and resources of cfg's rules. The core group is
+// omitted and wildcards become "all".
+func SuggestName(cfg InitializerConfiguration) string {
+	groups := make(map[string]bool)
+	resources := make(map[string]bool)
+	for _, initializer := range cfg.Initializers {
+		for _, rule := range initializer.Rules {
+			for _, group := range rule.APIGroups {
+				if group != "" {
+					groups[group] = true
+				}
+			}
+			for _, resource := range rule.Resources {
+				resources[resource] = true
+			}
+		}
+	}
+	var parts []string
+	for _, set := range []map[string]bool{groups, resources} {
+		seen := make(map[string]bool)
+		for _, v := range sortedKeys(set) {
+			if v == allWithSubresources {
+				v = wildcard
+			}
+			v = strings.Replace(strings.ToLower(v), wildcard, "all", -1)
+			v = strings.Trim(nonDNSLabelChars.ReplaceAllString(v, "-"), "-")
+			if v != "" && !seen[v] {
+				seen[v] = true
+				parts = append(parts, v)
+			}
+		}
+	}
+	name := strings.Join(parts, "-")
+	if max := validation.DNS1123SubdomainMaxLength - len(suggestedNameSuffix); len(name) > max {
+		name = strings.TrimRight(name[:max], "-")
+	}
+	if name == "" {
+		name = "empty"
+	}
+	return name + suggestedNameSuffix
+}
@@ -0,0 +1,86 @@
+/**
+ * Copyright (C) 2015 Red Hat, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *         http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package initializers
+
+import (
+	"strings"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/util/validation"
+)
+
+func TestSuggestName(t *testing.T) {
+	tests := []struct {
+		name  string
+		rules []Rule
+		want  string
+	}{
+		{
+			name:  "single resource",
+			rules: []Rule{{APIGroups: []string{"apps"}, APIVersions: []string{"v1"}, Resources: []string{"deployments"}}},
+			want:  "apps-deployments-init",
+		},
+		{
+			name: "sorted and deduplicated",
+			rules: []Rule{
+				{APIGroups: []string{"batch", ""}, APIVersions: []string{"v1"}, Resources: []string{"jobs", "pods"}},
+				{APIGroups: []string{"apps", "batch"}, APIVersions: []string{"v1"}, Resources: []string{"deployments/scale", "jobs"}},
+			},
+			want: "apps-batch-deployments-scale-jobs-pods-init",
+		},
+		{
+			name:  "wildcards",
+			rules: []Rule{{APIGroups: []string{"*"}, APIVersions: []string{"*"}, Resources: []string{"*", "*/*"}}},
+			want:  "all-all-init",
+		},
+		{
+			name: "no rules",
+			want: "empty-init",
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			cfg := InitializerConfiguration{Initializers: []Initializer{{Name: "a.example.com", Rules: tc.rules}}}
+			if got := SuggestName(cfg); got != tc.want {
+				t.Errorf("expected %q, got %q", tc.want, got)
+			}
+			if got := SuggestName(cfg); got != tc.want {
+				t.Errorf("expected a stable name %q, got %q", tc.want, got)
+			}
+		})
+	}
+}
+
+func TestSuggestNameTruncates(t *testing.T) {
+	var resources []string
+	for i := 0; i < 40; i++ {
+		resources = append(resources, strings.Repeat(string(rune('a'+i%26)), 10)+"s")
+	}
+	cfg := InitializerConfiguration{Initializers: []Initializer{{
+		Name:  "a.example.com",
+		Rules: []Rule{{APIGroups: []string{"apps"}, APIVersions: []string{"v1"}, Resources: resources}},
+	}}}
+	name := SuggestName(cfg)
+	if len(name) > validation.DNS1123SubdomainMaxLength {
+		t.Errorf("expected at most %d characters, got %d", validation.DNS1123SubdomainMaxLength, len(name))
+	}
+	if !strings.HasPrefix(name, "apps-") || !strings.HasSuffix(name, "-init") || strings.Contains(name, "--") {
+		t.Errorf("unexpected name %q", name)
+	}
+	if errs := validation.IsDNS1123Subdomain(name); len(errs) != 0 {
+		t.Errorf("expected a valid subdomain, got %v", errs)
+	}
+}
@@ -0,0 +1,79 @@
+/**
+ * Copyright (C) 2015 Red Hat, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *         http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package initializers
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+// ValidationResult reports the outcome for one line of an NDJSON stream. Err is
+// set when the line could not be read or decoded, in which case Errors is
+// empty.
+type ValidationResult struct {
+	Line   int
+	Name   string
+	Errors field.ErrorList
+	Err    error
+}
+
+// ValidateNDJSONStream decodes and validates the configs of r one line at a
+// time, emitting a result per non-blank line. Lines must be strict JSON, so
+// YAML and unknown fields are reported as decode errors. The channel is closed
+// at the end of r and must be drained by the caller.
+func ValidateNDJSONStream(r io.Reader) (<-chan ValidationResult, error) {
+	if r == nil {
+		return nil, errors.New("nil reader")
+	}
+	results := make(chan ValidationResult)
+	go func() {
+		defer close(results)
+		// bufio.Reader rather than a Scanner, so lines have no size limit.
+		reader := bufio.NewReader(r)
+		for line := 1; ; line++ {
+			data, err := reader.ReadBytes('\n')
+			if err != nil && err != io.EOF {
+				results <- ValidationResult{Line: line, Err: err}
+				return
+			}
+			if len(bytes.TrimSpace(data)) > 0 {
+				results <- validateNDJSONLine(line, data)
+			}
+			if err == io.EOF {
+				return
+			}
+		}
+	}()
+	return results, nil
+}
+
+func validateNDJSONLine(line int, data []byte) ValidationResult {
+	var cfg InitializerConfiguration
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&cfg); err != nil {
+		return ValidationResult{Line: line, Err: err}
+	}
+	if decoder.More() {
+		return ValidationResult{Line: line, Err: errors.New("unexpected data after the config")}
+	}
+	return ValidationResult{Line: line, Name: cfg.Name, Errors: ValidateInitializerConfiguration(cfg)}
+}
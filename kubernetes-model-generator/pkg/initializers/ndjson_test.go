@@ -0,0 +1,69 @@
+/**
+ * Copyright (C) 2015 Red Hat, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *         http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package initializers
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestValidateNDJSONStream(t *testing.T) {
+	input := strings.Join([]string{
+		`{"apiVersion":"admissionregistration.k8s.io/v1alpha1","kind":"InitializerConfiguration","metadata":{"name":"good"},"initializers":[{"name":"a.example.com","rules":[{"apiGroups":[""],"apiVersions":["v1"],"resources":["pods"]}]}]}`,
+		`{"metadata":{"name":"invalid"},"initializers":[{"name":"bad"}]}`,
+		``,
+		`{"metadata":{"name":`,
+		`metadata: {name: yaml}`,
+		`{"metadata":{"name":"typo"},"initalizers":[]}`,
+		`{"metadata":{"name":"twice"}} {}`,
+		`{"metadata":{"name":"last"}}`,
+	}, "\n")
+	results, err := ValidateNDJSONStream(strings.NewReader(input))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got []ValidationResult
+	for result := range results {
+		got = append(got, result)
+	}
+	if len(got) != 7 {
+		t.Fatalf("expected 7 results, got %+v", got)
+	}
+	want := []struct {
+		line     int
+		name     string
+		errs     int
+		parseErr bool
+	}{
+		{line: 1, name: "good"},
+		{line: 2, name: "invalid", errs: 1},
+		{line: 4, parseErr: true},
+		{line: 5, parseErr: true},
+		{line: 6, parseErr: true},
+		{line: 7, parseErr: true},
+		{line: 8, name: "last"},
+	}
+	for i, w := range want {
+		r := got[i]
+		if r.Line != w.line || r.Name != w.name || len(r.Errors) != w.errs || (r.Err != nil) != w.parseErr {
+			t.Errorf("result %d: expected %+v, got %+v", i, w, r)
+		}
+	}
+
+	if _, err := ValidateNDJSONStream(nil); err == nil {
+		t.Error("expected an error for a nil reader")
+	}
+}
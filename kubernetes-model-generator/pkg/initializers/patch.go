@@ -0,0 +1,57 @@
+/**
+ * Copyright (C) 2015 Red Hat, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *         http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package initializers
+
+import (
+	"encoding/json"
+
+	jsonpatch "github.com/evanphx/json-patch"
+	"k8s.io/apimachinery/pkg/util/strategicpatch"
+)
+
+// StrategicDelta returns the strategic merge patch turning current into
+// desired. Initializers merge by name, and since their order decides which
+// initializer runs first the patch carries a $setElementOrder directive
+// whenever they change.
+func StrategicDelta(current, desired InitializerConfiguration) ([]byte, error) {
+	original, err := json.Marshal(current)
+	if err != nil {
+		return nil, err
+	}
+	modified, err := json.Marshal(desired)
+	if err != nil {
+		return nil, err
+	}
+	return strategicpatch.CreateTwoWayMergePatch(original, modified, InitializerConfiguration{})
+}
+
+// PolicyFunc returns the compliant form of cfg, for example with wildcards
+// collapsed or required annotations added. It may modify cfg in place.
+type PolicyFunc func(cfg InitializerConfiguration) InitializerConfiguration
+
+// RemediateForPolicy returns the JSON merge patch (RFC 7386) bringing cfg to the
+// form policy asks for. The patch is empty ("{}") when cfg already complies.
+func RemediateForPolicy(cfg InitializerConfiguration, policy PolicyFunc) (patch []byte, err error) {
+	original, err := json.Marshal(cfg)
+	if err != nil {
+		return nil, err
+	}
+	modified, err := json.Marshal(policy(*cfg.DeepCopy()))
+	if err != nil {
+		return nil, err
+	}
+	return jsonpatch.CreateMergePatch(original, modified)
+}
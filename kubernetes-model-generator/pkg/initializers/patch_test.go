@@ -0,0 +1,134 @@
+/**
+ * Copyright (C) 2015 Red Hat, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *         http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package initializers
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	jsonpatch "github.com/evanphx/json-patch"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/strategicpatch"
+)
+
+func TestStrategicDelta(t *testing.T) {
+	pods := Rule{APIGroups: []string{""}, APIVersions: []string{"v1"}, Resources: []string{"pods"}}
+	current := InitializerConfiguration{
+		ObjectMeta: metav1.ObjectMeta{Name: "example"},
+		Initializers: []Initializer{
+			{Name: "a.example.com", Rules: []Rule{pods}},
+			{Name: "b.example.com", Rules: []Rule{pods}},
+			{Name: "c.example.com", Rules: []Rule{pods}},
+		},
+	}
+	desired := *current.DeepCopy()
+	desired.Initializers = []Initializer{
+		{Name: "c.example.com", Rules: []Rule{pods}},
+		{Name: "a.example.com", Rules: []Rule{pods}},
+		{Name: "d.example.com", Rules: []Rule{pods}},
+	}
+
+	patch, err := StrategicDelta(current, desired)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var directives map[string]interface{}
+	if err := json.Unmarshal(patch, &directives); err != nil {
+		t.Fatal(err)
+	}
+	wantOrder := []interface{}{
+		map[string]interface{}{"name": "c.example.com"},
+		map[string]interface{}{"name": "a.example.com"},
+		map[string]interface{}{"name": "d.example.com"},
+	}
+	if got := directives["$setElementOrder/initializers"]; !reflect.DeepEqual(got, wantOrder) {
+		t.Errorf("expected $setElementOrder/initializers %v, got %v in %s", wantOrder, got, patch)
+	}
+
+	original, err := json.Marshal(current)
+	if err != nil {
+		t.Fatal(err)
+	}
+	patched, err := strategicpatch.StrategicMergePatch(original, patch, InitializerConfiguration{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got InitializerConfiguration
+	if err := json.Unmarshal(patched, &got); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(got.Initializers, desired.Initializers) {
+		t.Errorf("expected initializers %+v, got %+v", desired.Initializers, got.Initializers)
+	}
+}
+
+func TestRemediateForPolicy(t *testing.T) {
+	cfg := InitializerConfiguration{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   "example",
+			Labels: map[string]string{"team": "a", "stale": "true"},
+		},
+		Initializers: []Initializer{{
+			Name:  "a.example.com",
+			Rules: []Rule{{APIGroups: []string{"apps", "*"}, APIVersions: []string{"v1"}, Resources: []string{"deployments"}}},
+		}},
+	}
+	original := *cfg.DeepCopy()
+	policy := func(cfg InitializerConfiguration) InitializerConfiguration {
+		FixWildcards(&cfg)
+		if cfg.Annotations == nil {
+			cfg.Annotations = map[string]string{}
+		}
+		cfg.Annotations[OwnerAnnotation] = "platform"
+		delete(cfg.Labels, "stale")
+		return cfg
+	}
+	compliant := func(cfg InitializerConfiguration) bool {
+		return reflect.DeepEqual(policy(*cfg.DeepCopy()), cfg)
+	}
+
+	patch, err := RemediateForPolicy(cfg, policy)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(cfg, original) {
+		t.Error("expected cfg not to be modified")
+	}
+	doc, err := json.Marshal(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	patched, err := jsonpatch.MergePatch(doc, patch)
+	if err != nil {
+		t.Fatalf("applying %s: %v", patch, err)
+	}
+	var remediated InitializerConfiguration
+	if err := json.Unmarshal(patched, &remediated); err != nil {
+		t.Fatal(err)
+	}
+	if !compliant(remediated) {
+		t.Errorf("expected %s to bring the config into compliance, got %+v", patch, remediated)
+	}
+
+	noop, err := RemediateForPolicy(remediated, policy)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(noop) != "{}" {
+		t.Errorf("expected an empty patch for a compliant config, got %s", noop)
+	}
+}
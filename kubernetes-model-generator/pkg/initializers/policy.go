@@ -0,0 +1,204 @@
+/**
+ * Copyright (C) 2015 Red Hat, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *         http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package initializers
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+// EnforceOrgSuffix flags initializer names outside the domain suffix, given
+// either as mycompany.com, .mycompany.com or *.mycompany.com.
+func EnforceOrgSuffix(cfg InitializerConfiguration, suffix string) field.ErrorList {
+	suffix = normalizeOrgSuffix(suffix)
+	var allErrs field.ErrorList
+	for i, initializer := range cfg.Initializers {
+		if !strings.HasSuffix(initializer.Name, suffix) {
+			allErrs = append(allErrs, field.Invalid(initializerPath(i).Child("name"), initializer.Name, fmt.Sprintf("must end in %s", suffix)))
+		}
+	}
+	return allErrs
+}
+
+// normalizeOrgSuffix turns any accepted form of an org suffix into
+// .mycompany.com.
+func normalizeOrgSuffix(suffix string) string {
+	return "." + strings.TrimLeft(suffix, "*.")
+}
+
+// EnforceResourceAllowlist flags every group, version and resource combination
+// of a rule that no allowed GVR permits. A wildcard in a rule is only
+// permitted by a wildcard in the same position of an allowed GVR.
+func EnforceResourceAllowlist(cfg InitializerConfiguration, allowed []schema.GroupVersionResource) field.ErrorList {
+	var allErrs field.ErrorList
+	for i, initializer := range cfg.Initializers {
+		for j, rule := range initializer.Rules {
+			for _, group := range rule.APIGroups {
+				for _, version := range rule.APIVersions {
+					for _, resource := range rule.Resources {
+						gvr := schema.GroupVersionResource{Group: group, Version: version, Resource: resource}
+						if !allowlisted(gvr, allowed) {
+							allErrs = append(allErrs, field.Forbidden(rulePath(i, j), fmt.Sprintf("apiGroup %q, apiVersion %q, resource %q is not in the resource allowlist", group, version, resource)))
+						}
+					}
+				}
+			}
+		}
+	}
+	return allErrs
+}
+
+func allowlisted(gvr schema.GroupVersionResource, allowed []schema.GroupVersionResource) bool {
+	for _, a := range allowed {
+		if (a.Group == wildcard || a.Group == gvr.Group) &&
+			(a.Version == wildcard || a.Version == gvr.Version) &&
+			(a.Resource == wildcard || a.Resource == gvr.Resource) {
+			return true
+		}
+	}
+	return false
+}
+
+// EnforceMaxRulesPerInitializer flags initializers with more than max rules.
+func EnforceMaxRulesPerInitializer(cfg InitializerConfiguration, max int) field.ErrorList {
+	var allErrs field.ErrorList
+	for i, initializer := range cfg.Initializers {
+		if len(initializer.Rules) > max {
+			allErrs = append(allErrs, field.TooMany(initializerPath(i).Child("rules"), len(initializer.Rules), max))
+		}
+	}
+	return allErrs
+}
+
+// PreviewPolicyFilter partitions the initializer names of cfg by whether
+// policy accepts them. policy is given copies, so cfg is left untouched.
+func PreviewPolicyFilter(cfg InitializerConfiguration, policy func(Initializer) bool) (kept, removed []string) {
+	for i := range cfg.Initializers {
+		if policy(*cfg.Initializers[i].DeepCopy()) {
+			kept = append(kept, cfg.Initializers[i].Name)
+		} else {
+			removed = append(removed, cfg.Initializers[i].Name)
+		}
+	}
+	return kept, removed
+}
+
+// EnforceNameRegex flags the config name and initializer names that pattern
+// does not match. A nil pattern accepts every name.
+func EnforceNameRegex(cfg InitializerConfiguration, pattern *regexp.Regexp) field.ErrorList {
+	if pattern == nil {
+		return nil
+	}
+	var allErrs field.ErrorList
+	if !pattern.MatchString(cfg.Name) {
+		allErrs = append(allErrs, field.Invalid(field.NewPath("metadata", "name"), cfg.Name, fmt.Sprintf("must match %s", pattern)))
+	}
+	for i, initializer := range cfg.Initializers {
+		if !pattern.MatchString(initializer.Name) {
+			allErrs = append(allErrs, field.Invalid(initializerPath(i).Child("name"), initializer.Name, fmt.Sprintf("must match %s", pattern)))
+		}
+	}
+	return allErrs
+}
+
+// EnforceTotalRuleBudget flags cfg when its initializers hold more than budget
+// rules in total.
+func EnforceTotalRuleBudget(cfg InitializerConfiguration, budget int) field.ErrorList {
+	total := 0
+	for _, initializer := range cfg.Initializers {
+		total += len(initializer.Rules)
+	}
+	if total > budget {
+		return field.ErrorList{field.TooMany(field.NewPath("initializers"), total, budget)}
+	}
+	return nil
+}
+
+// EnforceAllowedVersions flags apiVersions entries missing from allowed. A
+// wildcard version is only accepted when allowed holds "*" itself.
+func EnforceAllowedVersions(cfg InitializerConfiguration, allowed []string) field.ErrorList {
+	var allErrs field.ErrorList
+	for i, initializer := range cfg.Initializers {
+		for j, rule := range initializer.Rules {
+			for k, version := range rule.APIVersions {
+				if !contains(allowed, version) {
+					allErrs = append(allErrs, field.NotSupported(rulePath(i, j).Child("apiVersions").Index(k), version, allowed))
+				}
+			}
+		}
+	}
+	return allErrs
+}
+
+// EnforceRequiredAnnotations flags every required annotation key missing from
+// cfg. An annotation with an empty value only counts as present when
+// allowEmpty is set.
+func EnforceRequiredAnnotations(cfg InitializerConfiguration, required []string, allowEmpty bool) field.ErrorList {
+	var allErrs field.ErrorList
+	annotationsPath := field.NewPath("metadata", "annotations")
+	for _, key := range required {
+		value, ok := cfg.Annotations[key]
+		if !ok || (value == "" && !allowEmpty) {
+			allErrs = append(allErrs, field.Required(annotationsPath.Key(key), "required annotation"))
+		}
+	}
+	return allErrs
+}
+
+// EnforceFinalizer flags cfg when its finalizers lack name.
+func EnforceFinalizer(cfg InitializerConfiguration, name string) field.ErrorList {
+	if contains(cfg.Finalizers, name) {
+		return nil
+	}
+	return field.ErrorList{field.Required(field.NewPath("metadata", "finalizers"), fmt.Sprintf("must include %q", name))}
+}
+
+// OwnerAnnotation names the owner of a config, required in production.
+const OwnerAnnotation = "owner"
+
+const productionEnv = "production"
+
+// EnforceProductionPolicy forbids wildcards and requires OwnerAnnotation when
+// env is "production". Other environments are not checked.
+func EnforceProductionPolicy(cfg InitializerConfiguration, env string) field.ErrorList {
+	if env != productionEnv {
+		return nil
+	}
+	var allErrs field.ErrorList
+	for i, initializer := range cfg.Initializers {
+		for j, rule := range initializer.Rules {
+			fldPath := rulePath(i, j)
+			allErrs = append(allErrs, forbidWildcards(rule.APIGroups, fldPath.Child("apiGroups"))...)
+			allErrs = append(allErrs, forbidWildcards(rule.APIVersions, fldPath.Child("apiVersions"))...)
+			allErrs = append(allErrs, forbidWildcards(rule.Resources, fldPath.Child("resources"))...)
+		}
+	}
+	return append(allErrs, EnforceRequiredAnnotations(cfg, []string{OwnerAnnotation}, false)...)
+}
+
+func forbidWildcards(values []string, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+	for i, value := range values {
+		if strings.Contains(value, wildcard) {
+			allErrs = append(allErrs, field.Forbidden(fldPath.Index(i), fmt.Sprintf("wildcard %q is not allowed in %s", value, productionEnv)))
+		}
+	}
+	return allErrs
+}
@@ -0,0 +1,279 @@
+/**
+ * Copyright (C) 2015 Red Hat, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *         http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package initializers
+
+import (
+	"reflect"
+	"regexp"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+func TestEnforceOrgSuffix(t *testing.T) {
+	cfg := testConfig("",
+		Initializer{Name: "podimage.mycompany.com"},
+		Initializer{Name: "quota.team.mycompany.com"},
+		Initializer{Name: "other.example.com"},
+		Initializer{Name: "mycompany.com"},
+		Initializer{Name: "evilmycompany.com"},
+	)
+	for _, suffix := range []string{"mycompany.com", ".mycompany.com", "*.mycompany.com"} {
+		t.Run(suffix, func(t *testing.T) {
+			errs := EnforceOrgSuffix(cfg, suffix)
+			var fields []string
+			for _, err := range errs {
+				fields = append(fields, err.Field)
+			}
+			want := []string{"initializers[2].name", "initializers[3].name", "initializers[4].name"}
+			if len(fields) != len(want) {
+				t.Fatalf("expected errors for %v, got %v", want, errs)
+			}
+			for i := range want {
+				if fields[i] != want[i] {
+					t.Errorf("expected error for %s, got %s", want[i], fields[i])
+				}
+			}
+		})
+	}
+	if errs := EnforceOrgSuffix(testConfig("", Initializer{Name: "podimage.mycompany.com"}), "*.mycompany.com"); len(errs) != 0 {
+		t.Errorf("expected a compliant config, got %v", errs)
+	}
+}
+
+func TestEnforceResourceAllowlist(t *testing.T) {
+	allowed := []schema.GroupVersionResource{
+		{Group: "", Version: "v1", Resource: "pods"},
+		{Group: "apps", Version: "v1", Resource: "deployments"},
+		{Group: "apps", Version: "*", Resource: "statefulsets"},
+	}
+	tests := []struct {
+		name     string
+		rule     Rule
+		wantErrs int
+	}{
+		{
+			name: "allowed",
+			rule: Rule{APIGroups: []string{"apps"}, APIVersions: []string{"v1"}, Resources: []string{"deployments", "statefulsets"}},
+		},
+		{
+			name:     "concrete resource",
+			rule:     Rule{APIGroups: []string{""}, APIVersions: []string{"v1"}, Resources: []string{"pods", "secrets"}},
+			wantErrs: 1,
+		},
+		{
+			name:     "wildcard",
+			rule:     Rule{APIGroups: []string{"apps"}, APIVersions: []string{"*"}, Resources: []string{"deployments", "statefulsets"}},
+			wantErrs: 1,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			errs := EnforceResourceAllowlist(testConfig("", Initializer{Name: "a.example.com", Rules: []Rule{tc.rule}}), allowed)
+			if len(errs) != tc.wantErrs {
+				t.Fatalf("expected %d errors, got %v", tc.wantErrs, errs)
+			}
+			for _, err := range errs {
+				if err.Type != field.ErrorTypeForbidden || err.Field != "initializers[0].rules[0]" {
+					t.Errorf("unexpected error %v", err)
+				}
+			}
+		})
+	}
+}
+
+func TestEnforceMaxRulesPerInitializer(t *testing.T) {
+	pods := Rule{APIGroups: []string{""}, APIVersions: []string{"v1"}, Resources: []string{"pods"}}
+	cfg := testConfig("",
+		Initializer{Name: "a.example.com", Rules: []Rule{pods}},
+		Initializer{Name: "b.example.com", Rules: []Rule{pods, pods}},
+		Initializer{Name: "c.example.com", Rules: []Rule{pods, pods, pods}},
+	)
+	errs := EnforceMaxRulesPerInitializer(cfg, 2)
+	if len(errs) != 1 {
+		t.Fatalf("expected 1 error, got %v", errs)
+	}
+	if errs[0].Type != field.ErrorTypeTooMany || errs[0].Field != "initializers[2].rules" {
+		t.Errorf("unexpected error %v", errs[0])
+	}
+	if errs := EnforceMaxRulesPerInitializer(testConfig("", cfg.Initializers[1], cfg.Initializers[1]), 2); len(errs) != 0 {
+		t.Errorf("expected no errors at the limit, got %v", errs)
+	}
+}
+
+func TestPreviewPolicyFilter(t *testing.T) {
+	cfg := InitializerConfiguration{Initializers: []Initializer{
+		{Name: "narrow.example.com", Rules: []Rule{{APIGroups: []string{""}, APIVersions: []string{"v1"}, Resources: []string{"pods"}}}},
+		{Name: "broad.example.com", Rules: []Rule{{APIGroups: []string{"*"}, APIVersions: []string{"*"}, Resources: []string{"*"}}}},
+		{Name: "mixed.example.com", Rules: []Rule{{APIGroups: []string{"apps"}, APIVersions: []string{"*"}, Resources: []string{"deployments"}}}},
+	}}
+	original := *cfg.DeepCopy()
+	// Rejects initializers with wildcards in two or more fields, and mutates
+	// what it is given to check that it only sees copies.
+	policy := func(initializer Initializer) bool {
+		heavy := len(wildcardFields(initializer)) >= 2
+		initializer.Rules[0].APIGroups[0] = "mutated"
+		initializer.Name = "mutated"
+		return !heavy
+	}
+	kept, removed := PreviewPolicyFilter(cfg, policy)
+	if want := []string{"narrow.example.com", "mixed.example.com"}; !reflect.DeepEqual(kept, want) {
+		t.Errorf("expected kept %v, got %v", want, kept)
+	}
+	if want := []string{"broad.example.com"}; !reflect.DeepEqual(removed, want) {
+		t.Errorf("expected removed %v, got %v", want, removed)
+	}
+	if !reflect.DeepEqual(cfg, original) {
+		t.Errorf("expected cfg not to be mutated, got %+v", cfg)
+	}
+}
+
+func TestEnforceNameRegex(t *testing.T) {
+	pattern := regexp.MustCompile(`^[a-z]+(\.example\.com)?$`)
+	cfg := testConfig("config", Initializer{Name: "good.example.com"}, Initializer{Name: "Bad.example.com"}, Initializer{Name: "other.io"})
+	errs := EnforceNameRegex(cfg, pattern)
+	if len(errs) != 2 {
+		t.Fatalf("expected 2 errors, got %v", errs)
+	}
+	for i, want := range []string{"initializers[1].name", "initializers[2].name"} {
+		if errs[i].Type != field.ErrorTypeInvalid || errs[i].Field != want {
+			t.Errorf("expected an invalid value at %s, got %v", want, errs[i])
+		}
+	}
+
+	cfg.Name = "Config"
+	if errs := EnforceNameRegex(cfg, pattern); len(errs) != 3 || errs[0].Field != "metadata.name" {
+		t.Errorf("expected the config name to be flagged first, got %v", errs)
+	}
+	if errs := EnforceNameRegex(testConfig("", Initializer{Name: "good.example.com"}), pattern); len(errs) != 1 {
+		t.Errorf("expected only the empty config name to be flagged, got %v", errs)
+	}
+	if errs := EnforceNameRegex(cfg, nil); errs != nil {
+		t.Errorf("expected a nil pattern to accept every name, got %v", errs)
+	}
+}
+
+func TestEnforceTotalRuleBudget(t *testing.T) {
+	pods := Rule{APIGroups: []string{""}, APIVersions: []string{"v1"}, Resources: []string{"pods"}}
+	cfg := testConfig("",
+		Initializer{Name: "a.example.com", Rules: []Rule{pods, pods}},
+		Initializer{Name: "b.example.com", Rules: []Rule{pods}},
+	)
+	for budget, wantErrs := range map[int]int{4: 0, 3: 0, 2: 1} {
+		errs := EnforceTotalRuleBudget(cfg, budget)
+		if len(errs) != wantErrs {
+			t.Errorf("budget %d: expected %d errors, got %v", budget, wantErrs, errs)
+			continue
+		}
+		if wantErrs > 0 && (errs[0].Type != field.ErrorTypeTooMany || errs[0].Field != "initializers") {
+			t.Errorf("budget %d: unexpected error %v", budget, errs[0])
+		}
+	}
+}
+
+func TestEnforceAllowedVersions(t *testing.T) {
+	allowed := []string{"v1", "v1beta1"}
+	if errs := EnforceAllowedVersions(testConfig("", Initializer{Name: "a.example.com", Rules: []Rule{{APIVersions: []string{"v1", "v1beta1"}}}}), allowed); len(errs) != 0 {
+		t.Errorf("expected allowed versions to pass, got %v", errs)
+	}
+	errs := EnforceAllowedVersions(testConfig("", Initializer{Name: "a.example.com", Rules: []Rule{{APIVersions: []string{"v1", "v2alpha1", "*"}}}}), allowed)
+	if len(errs) != 2 {
+		t.Fatalf("expected 2 errors, got %v", errs)
+	}
+	for i, want := range []string{"initializers[0].rules[0].apiVersions[1]", "initializers[0].rules[0].apiVersions[2]"} {
+		if errs[i].Type != field.ErrorTypeNotSupported || errs[i].Field != want {
+			t.Errorf("expected an unsupported value at %s, got %v", want, errs[i])
+		}
+	}
+	if errs := EnforceAllowedVersions(testConfig("", Initializer{Name: "a.example.com", Rules: []Rule{{APIVersions: []string{"*"}}}}), []string{"*"}); len(errs) != 0 {
+		t.Errorf("expected an allowed wildcard to pass, got %v", errs)
+	}
+}
+
+func TestEnforceRequiredAnnotations(t *testing.T) {
+	var cfg InitializerConfiguration
+	cfg.Annotations = map[string]string{"owner": "me", "team": ""}
+	tests := []struct {
+		name       string
+		required   []string
+		allowEmpty bool
+		want       []string
+	}{
+		{name: "present", required: []string{"owner"}},
+		{name: "missing", required: []string{"owner", "cost-center"}, want: []string{"metadata.annotations[cost-center]"}},
+		{name: "empty value", required: []string{"team"}, want: []string{"metadata.annotations[team]"}},
+		{name: "empty value allowed", required: []string{"team"}, allowEmpty: true},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			var got []string
+			for _, err := range EnforceRequiredAnnotations(cfg, tc.required, tc.allowEmpty) {
+				if err.Type != field.ErrorTypeRequired {
+					t.Errorf("unexpected error %v", err)
+				}
+				got = append(got, err.Field)
+			}
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("expected errors at %v, got %v", tc.want, got)
+			}
+		})
+	}
+}
+
+func TestEnforceFinalizer(t *testing.T) {
+	var cfg InitializerConfiguration
+	cfg.Finalizers = []string{"other.example.com/cleanup", "initializers.example.com/cleanup"}
+	if errs := EnforceFinalizer(cfg, "initializers.example.com/cleanup"); len(errs) != 0 {
+		t.Errorf("expected no errors, got %v", errs)
+	}
+	errs := EnforceFinalizer(cfg, "missing.example.com/cleanup")
+	if len(errs) != 1 || errs[0].Type != field.ErrorTypeRequired || errs[0].Field != "metadata.finalizers" {
+		t.Errorf("expected a required finalizer error, got %v", errs)
+	}
+}
+
+func TestEnforceProductionPolicy(t *testing.T) {
+	cfg := InitializerConfiguration{Initializers: []Initializer{{
+		Name: "a.example.com",
+		Rules: []Rule{
+			{APIGroups: []string{"*"}, APIVersions: []string{"v1"}, Resources: []string{"pods", "pods/*"}},
+			{APIGroups: []string{"apps"}, APIVersions: []string{"v1"}, Resources: []string{"deployments"}},
+		},
+	}}}
+	var got []string
+	for _, err := range EnforceProductionPolicy(cfg, "production") {
+		got = append(got, err.Field)
+	}
+	want := []string{
+		"initializers[0].rules[0].apiGroups[0]",
+		"initializers[0].rules[0].resources[1]",
+		"metadata.annotations[owner]",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("expected errors at %v, got %v", want, got)
+	}
+	if errs := EnforceProductionPolicy(cfg, "staging"); len(errs) != 0 {
+		t.Errorf("expected staging to be lenient, got %v", errs)
+	}
+
+	compliant := *cfg.DeepCopy()
+	compliant.Initializers[0].Rules = compliant.Initializers[0].Rules[1:]
+	compliant.Annotations = map[string]string{OwnerAnnotation: "platform"}
+	if errs := EnforceProductionPolicy(compliant, "production"); len(errs) != 0 {
+		t.Errorf("expected a compliant production config, got %v", errs)
+	}
+}
@@ -0,0 +1,163 @@
+/**
+ * Copyright (C) 2015 Red Hat, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *         http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package initializers
+
+import (
+	"fmt"
+
+	"google.golang.org/protobuf/encoding/protowire"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/util/diff"
+)
+
+// The wire format follows the field numbers of the v1alpha1 generated.proto:
+//
+//	InitializerConfiguration: metadata = 1, initializers = 2
+//	Initializer:              name = 1, rules = 2
+//	Rule:                     apiGroups = 1, apiVersions = 2, resources = 3
+//
+// TypeMeta is carried by the runtime.Unknown envelope rather than the message.
+
+// ValidateProtoRoundTrip encodes cfg to protobuf, decodes it again and
+// reports any semantic difference between the two.
+func ValidateProtoRoundTrip(cfg InitializerConfiguration) error {
+	data, err := marshalProto(cfg)
+	if err != nil {
+		return fmt.Errorf("marshalling %q to protobuf: %v", cfg.Name, err)
+	}
+	decoded, err := unmarshalProto(data)
+	if err != nil {
+		return fmt.Errorf("unmarshalling %q from protobuf: %v", cfg.Name, err)
+	}
+	expected := cfg
+	expected.TypeMeta = decoded.TypeMeta
+	if !equality.Semantic.DeepEqual(expected, decoded) {
+		return fmt.Errorf("protobuf round trip of %q is lossy: %s", cfg.Name, diff.ObjectReflectDiff(expected, decoded))
+	}
+	return nil
+}
+
+func marshalProto(cfg InitializerConfiguration) ([]byte, error) {
+	meta, err := cfg.ObjectMeta.Marshal()
+	if err != nil {
+		return nil, err
+	}
+	b := protowire.AppendTag(nil, 1, protowire.BytesType)
+	b = protowire.AppendBytes(b, meta)
+	for _, initializer := range cfg.Initializers {
+		b = protowire.AppendTag(b, 2, protowire.BytesType)
+		b = protowire.AppendBytes(b, marshalInitializerProto(initializer))
+	}
+	return b, nil
+}
+
+func marshalInitializerProto(initializer Initializer) []byte {
+	b := protowire.AppendTag(nil, 1, protowire.BytesType)
+	b = protowire.AppendString(b, initializer.Name)
+	for _, rule := range initializer.Rules {
+		var r []byte
+		r = appendProtoStrings(r, 1, rule.APIGroups)
+		r = appendProtoStrings(r, 2, rule.APIVersions)
+		r = appendProtoStrings(r, 3, rule.Resources)
+		b = protowire.AppendTag(b, 2, protowire.BytesType)
+		b = protowire.AppendBytes(b, r)
+	}
+	return b
+}
+
+func appendProtoStrings(b []byte, num protowire.Number, values []string) []byte {
+	for _, v := range values {
+		b = protowire.AppendTag(b, num, protowire.BytesType)
+		b = protowire.AppendString(b, v)
+	}
+	return b
+}
+
+func unmarshalProto(data []byte) (InitializerConfiguration, error) {
+	var cfg InitializerConfiguration
+	err := consumeProtoFields(data, func(num protowire.Number, value []byte) error {
+		switch num {
+		case 1:
+			return cfg.ObjectMeta.Unmarshal(value)
+		case 2:
+			initializer, err := unmarshalInitializerProto(value)
+			if err != nil {
+				return err
+			}
+			cfg.Initializers = append(cfg.Initializers, initializer)
+		}
+		return nil
+	})
+	return cfg, err
+}
+
+func unmarshalInitializerProto(data []byte) (Initializer, error) {
+	var initializer Initializer
+	err := consumeProtoFields(data, func(num protowire.Number, value []byte) error {
+		switch num {
+		case 1:
+			initializer.Name = string(value)
+		case 2:
+			var rule Rule
+			err := consumeProtoFields(value, func(num protowire.Number, value []byte) error {
+				switch num {
+				case 1:
+					rule.APIGroups = append(rule.APIGroups, string(value))
+				case 2:
+					rule.APIVersions = append(rule.APIVersions, string(value))
+				case 3:
+					rule.Resources = append(rule.Resources, string(value))
+				}
+				return nil
+			})
+			if err != nil {
+				return err
+			}
+			initializer.Rules = append(initializer.Rules, rule)
+		}
+		return nil
+	})
+	return initializer, err
+}
+
+// consumeProtoFields calls fn for every length-delimited field in data,
+// skipping fields of any other wire type.
+func consumeProtoFields(data []byte, fn func(num protowire.Number, value []byte) error) error {
+	for len(data) > 0 {
+		num, typ, n := protowire.ConsumeTag(data)
+		if n < 0 {
+			return protowire.ParseError(n)
+		}
+		data = data[n:]
+		if typ != protowire.BytesType {
+			n = protowire.ConsumeFieldValue(num, typ, data)
+			if n < 0 {
+				return protowire.ParseError(n)
+			}
+			data = data[n:]
+			continue
+		}
+		value, n := protowire.ConsumeBytes(data)
+		if n < 0 {
+			return protowire.ParseError(n)
+		}
+		data = data[n:]
+		if err := fn(num, value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
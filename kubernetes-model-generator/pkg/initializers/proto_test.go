@@ -0,0 +1,101 @@
+/**
+ * Copyright (C) 2015 Red Hat, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *         http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package initializers
+
+import (
+	"bytes"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestValidateProtoRoundTrip(t *testing.T) {
+	tests := []struct {
+		name string
+		cfg  InitializerConfiguration
+	}{
+		{
+			name: "representative",
+			cfg: InitializerConfiguration{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        "example",
+					Labels:      map[string]string{"team": "a"},
+					Annotations: map[string]string{"owner": "me"},
+				},
+				Initializers: []Initializer{
+					{Name: "a.example.com", Rules: []Rule{
+						{APIGroups: []string{"", "apps"}, APIVersions: []string{"v1"}, Resources: []string{"pods", "deployments/scale"}},
+						{APIGroups: []string{"*"}, APIVersions: []string{"*"}, Resources: []string{"*"}},
+					}},
+					{Name: "b.example.com"},
+				},
+			},
+		},
+		{
+			// Empty and absent repeated fields share one wire encoding.
+			name: "empty slices",
+			cfg: InitializerConfiguration{
+				ObjectMeta: metav1.ObjectMeta{Name: "empty"},
+				Initializers: []Initializer{{
+					Name:  "a.example.com",
+					Rules: []Rule{{APIGroups: []string{}, APIVersions: []string{}, Resources: []string{}}},
+				}},
+			},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if err := ValidateProtoRoundTrip(tc.cfg); err != nil {
+				t.Error(err)
+			}
+		})
+	}
+}
+
+func TestProtoFieldNumbers(t *testing.T) {
+	initializer := Initializer{
+		Name:  "a.b.c",
+		Rules: []Rule{{APIGroups: []string{""}, APIVersions: []string{"v1"}, Resources: []string{"pods"}}},
+	}
+	rule := []byte{
+		0x0a, 0x00, // apiGroups = 1
+		0x12, 0x02, 'v', '1', // apiVersions = 2
+		0x1a, 0x04, 'p', 'o', 'd', 's', // resources = 3
+	}
+	wantInitializer := append([]byte{
+		0x0a, 0x05, 'a', '.', 'b', '.', 'c', // name = 1
+		0x12, byte(len(rule)), // rules = 2
+	}, rule...)
+	if got := marshalInitializerProto(initializer); !bytes.Equal(got, wantInitializer) {
+		t.Errorf("initializer encoding\nexpected % x\ngot      % x", wantInitializer, got)
+	}
+
+	cfg := InitializerConfiguration{Initializers: []Initializer{initializer}}
+	meta, err := cfg.ObjectMeta.Marshal()
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := append([]byte{0x0a, byte(len(meta))}, meta...) // metadata = 1
+	want = append(want, 0x12, byte(len(wantInitializer)))  // initializers = 2
+	want = append(want, wantInitializer...)
+	got, err := marshalProto(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(got, want) {
+		t.Errorf("config encoding\nexpected % x\ngot      % x", want, got)
+	}
+}
@@ -0,0 +1,66 @@
+/**
+ * Copyright (C) 2015 Red Hat, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *         http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package initializers
+
+import (
+	"sort"
+)
+
+// DetectProvenanceCycles returns, sorted, the initializers whose provenance
+// chain never ends because it runs into a cycle. This includes initializers
+// that only lead into a cycle, such as d in d→a→b→c→a. provenance maps an
+// initializer to the one it was derived from; a chain ends at an initializer
+// without an entry.
+func DetectProvenanceCycles(provenance map[string]string) []string {
+	// cyclic caches the verdict for every initializer already walked.
+	cyclic := make(map[string]bool, len(provenance))
+	for start := range provenance {
+		if _, done := cyclic[start]; done {
+			continue
+		}
+		var chain []string
+		onChain := make(map[string]bool)
+		verdict := false
+		for name := start; ; {
+			if v, done := cyclic[name]; done {
+				verdict = v
+				break
+			}
+			if onChain[name] {
+				verdict = true
+				break
+			}
+			next, ok := provenance[name]
+			if !ok {
+				break
+			}
+			onChain[name] = true
+			chain = append(chain, name)
+			name = next
+		}
+		for _, name := range chain {
+			cyclic[name] = verdict
+		}
+	}
+	var names []string
+	for name, v := range cyclic {
+		if v {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
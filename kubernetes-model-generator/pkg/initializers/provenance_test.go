@@ -0,0 +1,51 @@
+/**
+ * Copyright (C) 2015 Red Hat, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *         http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package initializers
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestDetectProvenanceCycles(t *testing.T) {
+	tests := []struct {
+		name       string
+		provenance map[string]string
+		want       []string
+	}{
+		{
+			name:       "cycle with a lead-in",
+			provenance: map[string]string{"d": "a", "a": "b", "b": "c", "c": "a", "x": "y"},
+			want:       []string{"a", "b", "c", "d"},
+		},
+		{
+			name:       "self reference",
+			provenance: map[string]string{"a": "a", "b": "root"},
+			want:       []string{"a"},
+		},
+		{
+			name:       "clean chain",
+			provenance: map[string]string{"c": "b", "b": "a", "e": "b"},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := DetectProvenanceCycles(tc.provenance); !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("expected %v, got %v", tc.want, got)
+			}
+		})
+	}
+}
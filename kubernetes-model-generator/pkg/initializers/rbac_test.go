@@ -0,0 +1,39 @@
+/**
+ * Copyright (C) 2015 Red Hat, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *         http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package initializers
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestManagementRBACRules(t *testing.T) {
+	rules := ManagementRBACRules()
+	if len(rules) != 1 {
+		t.Fatalf("expected 1 rule, got %d", len(rules))
+	}
+	rule := rules[0]
+	if !reflect.DeepEqual(rule.APIGroups, []string{"admissionregistration.k8s.io"}) {
+		t.Errorf("unexpected apiGroups %v", rule.APIGroups)
+	}
+	if !reflect.DeepEqual(rule.Resources, []string{"initializerconfigurations"}) {
+		t.Errorf("unexpected resources %v", rule.Resources)
+	}
+	wantVerbs := []string{"get", "list", "watch", "create", "update", "delete"}
+	if !reflect.DeepEqual(rule.Verbs, wantVerbs) {
+		t.Errorf("expected verbs %v, got %v", wantVerbs, rule.Verbs)
+	}
+}
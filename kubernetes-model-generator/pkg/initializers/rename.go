@@ -0,0 +1,66 @@
+/**
+ * Copyright (C) 2015 Red Hat, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *         http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package initializers
+
+import (
+	"fmt"
+	"sort"
+
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+// SafeRenameMap checks proposed old to new initializer name renames against
+// the existing names. The returned map holds every rename that changes a name;
+// an error is returned if a new name is invalid, is proposed twice, or is
+// still held by an initializer that is not being renamed.
+func SafeRenameMap(existing []string, proposed map[string]string) (map[string]string, error) {
+	current := make(map[string]bool, len(existing))
+	for _, name := range existing {
+		current[name] = true
+	}
+	olds := make([]string, 0, len(proposed))
+	for old := range proposed {
+		olds = append(olds, old)
+	}
+	sort.Strings(olds)
+
+	renames := make(map[string]string, len(proposed))
+	claimedBy := make(map[string]string, len(proposed))
+	for _, old := range olds {
+		name := proposed[old]
+		if !current[old] {
+			return nil, fmt.Errorf("cannot rename %q: no such initializer", old)
+		}
+		if errs := validation.IsFullyQualifiedName(field.NewPath("name"), name); len(errs) > 0 {
+			return nil, fmt.Errorf("cannot rename %q to %q: %v", old, name, errs.ToAggregate())
+		}
+		if other, ok := claimedBy[name]; ok {
+			return nil, fmt.Errorf("cannot rename both %q and %q to %q", other, old, name)
+		}
+		claimedBy[name] = old
+		if name != old {
+			renames[old] = name
+		}
+	}
+	for _, old := range olds {
+		name := proposed[old]
+		if _, renamed := proposed[name]; current[name] && !renamed {
+			return nil, fmt.Errorf("cannot rename %q to %q: name is used by an initializer that is not renamed", old, name)
+		}
+	}
+	return renames, nil
+}
@@ -0,0 +1,80 @@
+/**
+ * Copyright (C) 2015 Red Hat, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *         http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package initializers
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestSafeRenameMap(t *testing.T) {
+	existing := []string{"a.example.com", "b.example.com", "c.example.com"}
+	tests := []struct {
+		name     string
+		proposed map[string]string
+		want     map[string]string
+		wantErr  string
+	}{
+		{
+			name:     "clean rename",
+			proposed: map[string]string{"a.example.com": "d.example.com", "c.example.com": "c.example.com"},
+			want:     map[string]string{"a.example.com": "d.example.com"},
+		},
+		{
+			name:     "swap",
+			proposed: map[string]string{"a.example.com": "b.example.com", "b.example.com": "a.example.com"},
+			want:     map[string]string{"a.example.com": "b.example.com", "b.example.com": "a.example.com"},
+		},
+		{
+			name:     "collides with an initializer kept as is",
+			proposed: map[string]string{"a.example.com": "b.example.com"},
+			wantErr:  `cannot rename "a.example.com" to "b.example.com": name is used`,
+		},
+		{
+			name:     "two renames to one name",
+			proposed: map[string]string{"a.example.com": "d.example.com", "b.example.com": "d.example.com"},
+			wantErr:  `cannot rename both "a.example.com" and "b.example.com" to "d.example.com"`,
+		},
+		{
+			name:     "unknown initializer",
+			proposed: map[string]string{"x.example.com": "d.example.com"},
+			wantErr:  "no such initializer",
+		},
+		{
+			name:     "invalid name",
+			proposed: map[string]string{"a.example.com": "bad"},
+			wantErr:  `cannot rename "a.example.com" to "bad"`,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := SafeRenameMap(existing, tc.proposed)
+			if tc.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tc.wantErr) {
+					t.Fatalf("expected error containing %q, got %v", tc.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("expected %v, got %v", tc.want, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,210 @@
+/**
+ * Copyright (C) 2015 Red Hat, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *         http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package initializers
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+)
+
+// SlackSummaryMaxLength caps the length in bytes of a SlackSummary.
+const SlackSummaryMaxLength = 300
+
+// RuleCounts maps each initializer name to the number of rules it declares.
+func RuleCounts(cfg InitializerConfiguration) map[string]int {
+	counts := make(map[string]int, len(cfg.Initializers))
+	for _, initializer := range cfg.Initializers {
+		counts[initializer.Name] += len(initializer.Rules)
+	}
+	return counts
+}
+
+// BlastSurfaceByKind maps each concrete resource named by the rules of cfg to
+// the number of initializers naming it. Initializers with a wildcard resource
+// pattern, such as "*" or "pods/*", are counted under "*" instead.
+func BlastSurfaceByKind(cfg InitializerConfiguration) map[string]int {
+	surface := make(map[string]int)
+	for _, initializer := range cfg.Initializers {
+		resources := make(map[string]bool)
+		for _, rule := range initializer.Rules {
+			for _, resource := range rule.Resources {
+				if strings.Contains(resource, wildcard) {
+					resource = wildcard
+				}
+				resources[resource] = true
+			}
+		}
+		for resource := range resources {
+			surface[resource]++
+		}
+	}
+	return surface
+}
+
+// SizeBreakdown attributes the JSON encoded size of cfg to its metadata, its
+// initializers excluding their rules, and the rules, each including its field
+// name. The remainder, which is mostly apiVersion and kind, is reported as
+// typeMeta so the sections sum to the total.
+func SizeBreakdown(cfg InitializerConfiguration) map[string]int {
+	rules := 0
+	for _, initializer := range cfg.Initializers {
+		if len(initializer.Rules) > 0 {
+			rules += len(`"rules":`) + encodedSize(initializer.Rules)
+		}
+	}
+	initializers := 0
+	if len(cfg.Initializers) > 0 {
+		initializers = len(`"initializers":`) + encodedSize(cfg.Initializers) - rules
+	}
+	metadata := len(`"metadata":`) + encodedSize(cfg.ObjectMeta)
+	return map[string]int{
+		"typeMeta":     encodedSize(cfg) - metadata - initializers - rules,
+		"metadata":     metadata,
+		"initializers": initializers,
+		"rules":        rules,
+	}
+}
+
+func encodedSize(v interface{}) int {
+	data, err := json.Marshal(v)
+	if err != nil {
+		// Only called with API types, which always encode.
+		panic(err)
+	}
+	return len(data)
+}
+
+// SlackSummary returns a short markdown summary of cfg listing the initializers
+// that use wildcards, at most SlackSummaryMaxLength bytes long. A long name is
+// shortened inside its bold markup, and wildcard entries that do not fit are
+// dropped whole and counted instead.
+func SlackSummary(cfg InitializerConfiguration) string {
+	rules := 0
+	var wildcards []string
+	for _, initializer := range cfg.Initializers {
+		rules += len(initializer.Rules)
+		if fields := wildcardFields(initializer); len(fields) > 0 {
+			wildcards = append(wildcards, fmt.Sprintf("`%s` (%s)", initializer.Name, strings.Join(fields, ", ")))
+		}
+	}
+	const title = "*InitializerConfiguration "
+	counts := fmt.Sprintf("*: %d initializers, %d rules", len(cfg.Initializers), rules)
+	// Leave room for at least the number of wildcard entries.
+	reserved := len(wildcardSummary(wildcards, 0))
+	summary := title + truncate(cfg.Name, SlackSummaryMaxLength-len(title)-len(counts)-reserved) + counts
+	for n := len(wildcards); n >= 0; n-- {
+		if line := wildcardSummary(wildcards, n); len(summary)+len(line) <= SlackSummaryMaxLength {
+			return summary + line
+		}
+	}
+	return summary
+}
+
+// wildcardSummary lists the first n entries, followed by how many were left
+// out.
+func wildcardSummary(entries []string, n int) string {
+	if len(entries) == 0 {
+		return ""
+	}
+	shown := entries[:n:n]
+	if n < len(entries) {
+		shown = append(shown, fmt.Sprintf("…and %d more", len(entries)-n))
+	}
+	return "\n:warning: Wildcards: " + strings.Join(shown, ", ")
+}
+
+// wildcardFields names the rule fields of initializer holding a wildcard.
+func wildcardFields(initializer Initializer) []string {
+	var groups, versions, resources bool
+	for _, rule := range initializer.Rules {
+		groups = groups || contains(rule.APIGroups, wildcard)
+		versions = versions || contains(rule.APIVersions, wildcard)
+		for _, resource := range rule.Resources {
+			resources = resources || strings.Contains(resource, wildcard)
+		}
+	}
+	var fields []string
+	if groups {
+		fields = append(fields, "apiGroups")
+	}
+	if versions {
+		fields = append(fields, "apiVersions")
+	}
+	if resources {
+		fields = append(fields, "resources")
+	}
+	return fields
+}
+
+func truncate(s string, max int) string {
+	const ellipsis = "…"
+	if len(s) <= max {
+		return s
+	}
+	cut := max - len(ellipsis)
+	for cut > 0 && !utf8.RuneStart(s[cut]) {
+		cut--
+	}
+	return s[:cut] + ellipsis
+}
+
+// ToMarkdownTable renders one GitHub flavored markdown table row per item,
+// listing its initializers, its total rule count and the initializers using
+// wildcards.
+func ToMarkdownTable(list InitializerConfigurationList) string {
+	var b strings.Builder
+	b.WriteString("| Name | Initializers | Rules | Wildcards |\n")
+	b.WriteString("| --- | --- | --- | --- |\n")
+	for _, item := range list.Items {
+		var names, wildcards []string
+		rules := 0
+		for _, initializer := range item.Initializers {
+			names = append(names, initializer.Name)
+			rules += len(initializer.Rules)
+			if len(wildcardFields(initializer)) > 0 {
+				wildcards = append(wildcards, initializer.Name)
+			}
+		}
+		fmt.Fprintf(&b, "| %s | %s | %d | %s |\n",
+			markdownCell(item.Name), markdownCell(strings.Join(names, ", ")), rules, markdownCell(strings.Join(wildcards, ", ")))
+	}
+	return b.String()
+}
+
+func markdownCell(s string) string {
+	s = strings.Replace(s, "\n", " ", -1)
+	return strings.Replace(s, "|", `\|`, -1)
+}
+
+// RuleMatrix returns one row per rule, in initializer and rule order, holding
+// the initializer name and the comma separated apiGroups, apiVersions and
+// resources of the rule.
+func RuleMatrix(cfg InitializerConfiguration) [][]string {
+	var rows [][]string
+	for _, initializer := range cfg.Initializers {
+		for _, rule := range initializer.Rules {
+			rows = append(rows, []string{
+				initializer.Name,
+				strings.Join(rule.APIGroups, ","),
+				strings.Join(rule.APIVersions, ","),
+				strings.Join(rule.Resources, ","),
+			})
+		}
+	}
+	return rows
+}
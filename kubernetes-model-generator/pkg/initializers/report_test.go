@@ -0,0 +1,193 @@
+/**
+ * Copyright (C) 2015 Red Hat, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *         http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package initializers
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"reflect"
+	"strings"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestRuleCounts(t *testing.T) {
+	cfg := InitializerConfiguration{Initializers: []Initializer{
+		{Name: "a.example.com", Rules: []Rule{
+			{APIGroups: []string{""}, APIVersions: []string{"v1"}, Resources: []string{"pods"}},
+			{APIGroups: []string{"apps"}, APIVersions: []string{"v1"}, Resources: []string{"deployments"}},
+		}},
+		{Name: "b.example.com", Rules: []Rule{
+			{APIGroups: []string{"batch"}, APIVersions: []string{"v1"}, Resources: []string{"jobs"}},
+		}},
+		{Name: "c.example.com"},
+	}}
+	want := map[string]int{"a.example.com": 2, "b.example.com": 1, "c.example.com": 0}
+	if got := RuleCounts(cfg); !reflect.DeepEqual(got, want) {
+		t.Errorf("expected %v, got %v", want, got)
+	}
+}
+
+func TestSizeBreakdown(t *testing.T) {
+	cfg := InitializerConfiguration{
+		TypeMeta:   metav1.TypeMeta{APIVersion: "v1", Kind: "K"},
+		ObjectMeta: metav1.ObjectMeta{Name: "x"},
+		Initializers: []Initializer{{
+			Name:  "a.b.c",
+			Rules: []Rule{{Resources: []string{"pods"}}},
+		}},
+	}
+	// {"kind":"K","apiVersion":"v1","metadata":{"name":"x","creationTimestamp":null},
+	// "initializers":[{"name":"a.b.c","rules":[{"resources":["pods"]}]}]}
+	want := map[string]int{
+		"typeMeta":     32,
+		"metadata":     len(`"metadata":{"name":"x","creationTimestamp":null}`),
+		"initializers": len(`"initializers":[{"name":"a.b.c",}]`),
+		"rules":        len(`"rules":[{"resources":["pods"]}]`),
+	}
+	got := SizeBreakdown(cfg)
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("expected %v, got %v", want, got)
+	}
+
+	data, err := json.Marshal(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	total := 0
+	for _, size := range got {
+		total += size
+	}
+	if total != len(data) {
+		t.Errorf("expected the sections to sum to %d, got %d", len(data), total)
+	}
+}
+
+func TestSlackSummary(t *testing.T) {
+	cfg := InitializerConfiguration{
+		ObjectMeta: metav1.ObjectMeta{Name: "example"},
+		Initializers: []Initializer{
+			{Name: "a.example.com", Rules: []Rule{
+				{APIGroups: []string{"*"}, APIVersions: []string{"v1"}, Resources: []string{"pods/*"}},
+				{APIGroups: []string{""}, APIVersions: []string{"v1"}, Resources: []string{"pods"}},
+			}},
+			{Name: "b.example.com", Rules: []Rule{
+				{APIGroups: []string{"apps"}, APIVersions: []string{"v1"}, Resources: []string{"deployments"}},
+			}},
+		},
+	}
+	want := "*InitializerConfiguration example*: 2 initializers, 3 rules\n:warning: Wildcards: `a.example.com` (apiGroups, resources)"
+	if got := SlackSummary(cfg); got != want {
+		t.Errorf("expected %q, got %q", want, got)
+	}
+
+	cfg.Name = strings.Repeat("n", 400)
+	got := SlackSummary(cfg)
+	expectBalancedSummary(t, got)
+	if !strings.HasPrefix(got, "*InitializerConfiguration nnn") || !strings.Contains(got, "n…*: 2 initializers, 3 rules\n") {
+		t.Errorf("expected the name to be truncated inside its markup, got %q", got)
+	}
+	if !strings.HasSuffix(got, ":warning: Wildcards: …and 1 more") {
+		t.Errorf("expected the wildcard entry to be counted, got %q", got)
+	}
+
+	cfg.Name = "example"
+	cfg.Initializers = nil
+	for i := 0; i < 20; i++ {
+		cfg.Initializers = append(cfg.Initializers, Initializer{
+			Name:  fmt.Sprintf("wildcard%02d.example.com", i),
+			Rules: []Rule{{APIGroups: []string{"*"}, APIVersions: []string{"*"}, Resources: []string{"pods"}}},
+		})
+	}
+	got = SlackSummary(cfg)
+	expectBalancedSummary(t, got)
+	if !strings.Contains(got, "`wildcard00.example.com` (apiGroups, apiVersions), ") || !strings.Contains(got, ", …and ") || !strings.HasSuffix(got, " more") {
+		t.Errorf("expected leading wildcard entries followed by a count, got %q", got)
+	}
+}
+
+func expectBalancedSummary(t *testing.T, summary string) {
+	t.Helper()
+	if len(summary) > SlackSummaryMaxLength {
+		t.Errorf("expected at most %d bytes, got %d", SlackSummaryMaxLength, len(summary))
+	}
+	if strings.Count(summary, "*")%2 != 0 || strings.Count(summary, "`")%2 != 0 || strings.Count(summary, "(") != strings.Count(summary, ")") {
+		t.Errorf("expected balanced markup, got %q", summary)
+	}
+}
+
+func TestToMarkdownTable(t *testing.T) {
+	pods := Rule{APIGroups: []string{""}, APIVersions: []string{"v1"}, Resources: []string{"pods"}}
+	list := InitializerConfigurationList{Items: []InitializerConfiguration{
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "a|b"},
+			Initializers: []Initializer{
+				{Name: "a.example.com", Rules: []Rule{pods, pods}},
+				{Name: "b.example.com", Rules: []Rule{{APIGroups: []string{"*"}, APIVersions: []string{"v1"}, Resources: []string{"pods"}}}},
+			},
+		},
+		{ObjectMeta: metav1.ObjectMeta{Name: "empty"}},
+	}}
+	golden, err := ioutil.ReadFile("testdata/markdown-table.golden")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := ToMarkdownTable(list); got != string(golden) {
+		t.Errorf("expected\n%s\ngot\n%s", golden, got)
+	}
+}
+
+func TestRuleMatrix(t *testing.T) {
+	cfg := InitializerConfiguration{Initializers: []Initializer{
+		{Name: "a.example.com", Rules: []Rule{
+			{APIGroups: []string{"", "apps"}, APIVersions: []string{"v1"}, Resources: []string{"pods", "deployments"}},
+			{APIGroups: []string{"batch"}, APIVersions: []string{"v1", "v1beta1"}, Resources: []string{"jobs"}},
+		}},
+		{Name: "b.example.com", Rules: []Rule{
+			{APIGroups: []string{"*"}, APIVersions: []string{"*"}, Resources: []string{"*/scale"}},
+		}},
+	}}
+	want := [][]string{
+		{"a.example.com", ",apps", "v1", "pods,deployments"},
+		{"a.example.com", "batch", "v1,v1beta1", "jobs"},
+		{"b.example.com", "*", "*", "*/scale"},
+	}
+	if got := RuleMatrix(cfg); !reflect.DeepEqual(got, want) {
+		t.Errorf("expected %q, got %q", want, got)
+	}
+}
+
+func TestBlastSurfaceByKind(t *testing.T) {
+	cfg := InitializerConfiguration{Initializers: []Initializer{
+		{Name: "a.example.com", Rules: []Rule{
+			{APIGroups: []string{""}, APIVersions: []string{"v1"}, Resources: []string{"pods", "services"}},
+			{APIGroups: []string{""}, APIVersions: []string{"v1beta1"}, Resources: []string{"pods"}},
+		}},
+		{Name: "b.example.com", Rules: []Rule{
+			{APIGroups: []string{""}, APIVersions: []string{"v1"}, Resources: []string{"pods", "pods/*"}},
+		}},
+		{Name: "c.example.com", Rules: []Rule{
+			{APIGroups: []string{"*"}, APIVersions: []string{"*"}, Resources: []string{"*", "*/scale"}},
+		}},
+	}}
+	// Each initializer counts once per resource, however many rules name it.
+	want := map[string]int{"pods": 2, "services": 1, "*": 2}
+	if got := BlastSurfaceByKind(cfg); !reflect.DeepEqual(got, want) {
+		t.Errorf("expected %v, got %v", want, got)
+	}
+}
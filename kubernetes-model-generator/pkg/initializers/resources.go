@@ -0,0 +1,40 @@
+/**
+ * Copyright (C) 2015 Red Hat, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *         http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package initializers
+
+import (
+	"sort"
+	"strings"
+)
+
+// SubresourceReferences returns the sorted, deduplicated resource/subresource
+// entries across all rules, such as pods/log or */scale.
+func SubresourceReferences(cfg InitializerConfiguration) []string {
+	seen := make(map[string]bool)
+	var refs []string
+	for _, initializer := range cfg.Initializers {
+		for _, rule := range initializer.Rules {
+			for _, resource := range rule.Resources {
+				if strings.Contains(resource, "/") && !seen[resource] {
+					seen[resource] = true
+					refs = append(refs, resource)
+				}
+			}
+		}
+	}
+	sort.Strings(refs)
+	return refs
+}
@@ -0,0 +1,45 @@
+/**
+ * Copyright (C) 2015 Red Hat, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *         http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package initializers
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestSubresourceReferences(t *testing.T) {
+	cfg := InitializerConfiguration{Initializers: []Initializer{
+		{Name: "a.example.com", Rules: []Rule{
+			{APIGroups: []string{""}, APIVersions: []string{"v1"}, Resources: []string{"pods", "pods/status", "pods/log"}},
+			{APIGroups: []string{"apps"}, APIVersions: []string{"v1"}, Resources: []string{"deployments"}},
+		}},
+		{Name: "b.example.com", Rules: []Rule{
+			{APIGroups: []string{"*"}, APIVersions: []string{"*"}, Resources: []string{"*/scale", "pods/log"}},
+		}},
+	}}
+	want := []string{"*/scale", "pods/log", "pods/status"}
+	if got := SubresourceReferences(cfg); !reflect.DeepEqual(got, want) {
+		t.Errorf("expected %v, got %v", want, got)
+	}
+
+	noSubresources := InitializerConfiguration{Initializers: []Initializer{{
+		Name:  "a.example.com",
+		Rules: []Rule{{APIGroups: []string{""}, APIVersions: []string{"v1"}, Resources: []string{"pods", "*"}}},
+	}}}
+	if got := SubresourceReferences(noSubresources); len(got) != 0 {
+		t.Errorf("expected no references, got %v", got)
+	}
+}
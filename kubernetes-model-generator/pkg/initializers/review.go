@@ -0,0 +1,61 @@
+/**
+ * Copyright (C) 2015 Red Hat, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *         http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package initializers
+
+import (
+	"encoding/json"
+	"fmt"
+
+	admissionv1 "k8s.io/api/admission/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ConfigFromReviewReplay builds a config named after initializerName whose
+// single initializer matches exactly the resource of a captured
+// admission.k8s.io/v1 or v1beta1 AdmissionReview, which share this layout.
+func ConfigFromReviewReplay(review []byte, initializerName string) (InitializerConfiguration, error) {
+	var cfg InitializerConfiguration
+	var ar admissionv1.AdmissionReview
+	if err := json.Unmarshal(review, &ar); err != nil {
+		return cfg, fmt.Errorf("decoding AdmissionReview: %v", err)
+	}
+	if ar.Kind != "AdmissionReview" {
+		return cfg, fmt.Errorf("expected an AdmissionReview, got kind %q", ar.Kind)
+	}
+	if ar.Request == nil {
+		return cfg, fmt.Errorf("AdmissionReview has no request")
+	}
+	resource := ar.Request.Resource
+	if resource.Version == "" || resource.Resource == "" {
+		return cfg, fmt.Errorf("AdmissionReview request %s has an incomplete resource %+v", ar.Request.UID, resource)
+	}
+	if ar.Request.SubResource != "" {
+		return cfg, fmt.Errorf("AdmissionReview request %s is for subresource %s/%s, which initializers cannot match", ar.Request.UID, resource.Resource, ar.Request.SubResource)
+	}
+	cfg = InitializerConfiguration{
+		TypeMeta:   metav1.TypeMeta{APIVersion: SchemeGroupVersion.String(), Kind: Kind},
+		ObjectMeta: metav1.ObjectMeta{Name: initializerName},
+		Initializers: []Initializer{{
+			Name: initializerName,
+			Rules: []Rule{{
+				APIGroups:   []string{resource.Group},
+				APIVersions: []string{resource.Version},
+				Resources:   []string{resource.Resource},
+			}},
+		}},
+	}
+	return cfg, nil
+}
@@ -0,0 +1,53 @@
+/**
+ * Copyright (C) 2015 Red Hat, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *         http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package initializers
+
+import (
+	"bytes"
+	"io/ioutil"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestConfigFromReviewReplay(t *testing.T) {
+	review, err := ioutil.ReadFile("testdata/admission-review.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	cfg, err := ConfigFromReviewReplay(review, "replay.example.com")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cfg.Name != "replay.example.com" || cfg.Kind != Kind || cfg.APIVersion != SchemeGroupVersion.String() {
+		t.Errorf("unexpected metadata %+v %+v", cfg.TypeMeta, cfg.ObjectMeta)
+	}
+	want := []Initializer{{
+		Name:  "replay.example.com",
+		Rules: []Rule{{APIGroups: []string{"apps"}, APIVersions: []string{"v1"}, Resources: []string{"deployments"}}},
+	}}
+	if !reflect.DeepEqual(cfg.Initializers, want) {
+		t.Errorf("expected %#v, got %#v", want, cfg.Initializers)
+	}
+
+	subresource := bytes.Replace(review, []byte(`"operation"`), []byte(`"subResource": "scale", "operation"`), 1)
+	if _, err := ConfigFromReviewReplay(subresource, "replay.example.com"); err == nil || !strings.Contains(err.Error(), "deployments/scale") {
+		t.Errorf("expected a subresource error, got %v", err)
+	}
+	if _, err := ConfigFromReviewReplay([]byte(`{"kind":"AdmissionReview"}`), "replay.example.com"); err == nil {
+		t.Error("expected an error for a review without request")
+	}
+}
@@ -0,0 +1,74 @@
+/**
+ * Copyright (C) 2015 Red Hat, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *         http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package initializers
+
+import (
+	"encoding/json"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestValidateListSARIF(t *testing.T) {
+	list := InitializerConfigurationList{Items: []InitializerConfiguration{
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "valid"},
+			Initializers: []Initializer{{
+				Name:  "a.example.com",
+				Rules: []Rule{{APIGroups: []string{""}, APIVersions: []string{"v1"}, Resources: []string{"pods"}}},
+			}},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "invalid"},
+			Initializers: []Initializer{{
+				Name:  "bad",
+				Rules: []Rule{{APIGroups: []string{""}, Resources: []string{"pods"}}},
+			}},
+		},
+	}}
+	data, err := ValidateListSARIF(list)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var log sarifLog
+	if err := json.Unmarshal(data, &log); err != nil {
+		t.Fatal(err)
+	}
+	if log.Version != "2.1.0" || len(log.Runs) != 1 {
+		t.Fatalf("unexpected log %s", data)
+	}
+	run := log.Runs[0]
+	if len(run.Tool.Driver.Rules) != 2 || run.Tool.Driver.Rules[0].ID != "FieldValueInvalid" || run.Tool.Driver.Rules[1].ID != "FieldValueRequired" {
+		t.Errorf("unexpected rules %+v", run.Tool.Driver.Rules)
+	}
+	want := []struct{ ruleID, location string }{
+		{"FieldValueInvalid", "invalid/initializers[0].name"},
+		{"FieldValueRequired", "invalid/initializers[0].rules[0].apiVersions"},
+	}
+	if len(run.Results) != len(want) {
+		t.Fatalf("expected %d results, got %s", len(want), data)
+	}
+	for i, w := range want {
+		result := run.Results[i]
+		if result.RuleID != w.ruleID || result.Level != "error" {
+			t.Errorf("result %d: expected rule %s, got %+v", i, w.ruleID, result)
+		}
+		if len(result.Locations) != 1 || len(result.Locations[0].LogicalLocations) != 1 ||
+			result.Locations[0].LogicalLocations[0].FullyQualifiedName != w.location {
+			t.Errorf("result %d: expected location %s, got %+v", i, w.location, result.Locations)
+		}
+	}
+}
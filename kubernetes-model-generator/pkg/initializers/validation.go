@@ -0,0 +1,178 @@
+/**
+ * Copyright (C) 2015 Red Hat, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *         http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package initializers
+
+import (
+	"fmt"
+	"strings"
+
+	genericvalidation "k8s.io/apimachinery/pkg/api/validation"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+// ValidateInitializerConfiguration ports the v1alpha1 validation the API
+// server applied before initializers were removed.
+func ValidateInitializerConfiguration(cfg InitializerConfiguration) field.ErrorList {
+	allErrs := genericvalidation.ValidateObjectMeta(&cfg.ObjectMeta, false, genericvalidation.NameIsDNSSubdomain, field.NewPath("metadata"))
+	for i, initializer := range cfg.Initializers {
+		allErrs = append(allErrs, validateInitializer(initializer, initializerPath(i))...)
+	}
+	return allErrs
+}
+
+func validateInitializer(initializer Initializer, fldPath *field.Path) field.ErrorList {
+	allErrs := validation.IsFullyQualifiedName(fldPath.Child("name"), initializer.Name)
+	for i, rule := range initializer.Rules {
+		allErrs = append(allErrs, validateRule(rule, fldPath.Child("rules").Index(i))...)
+	}
+	return allErrs
+}
+
+func validateRule(rule Rule, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+	if len(rule.APIGroups) == 0 {
+		allErrs = append(allErrs, field.Required(fldPath.Child("apiGroups"), ""))
+	}
+	if len(rule.APIGroups) > 1 && contains(rule.APIGroups, wildcard) {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("apiGroups"), rule.APIGroups, "if '*' is present, must not specify other API groups"))
+	}
+	// The group may be empty for the legacy core API, the version may not.
+	if len(rule.APIVersions) == 0 {
+		allErrs = append(allErrs, field.Required(fldPath.Child("apiVersions"), ""))
+	}
+	if len(rule.APIVersions) > 1 && contains(rule.APIVersions, wildcard) {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("apiVersions"), rule.APIVersions, "if '*' is present, must not specify other API versions"))
+	}
+	for i, version := range rule.APIVersions {
+		if version == "" {
+			allErrs = append(allErrs, field.Required(fldPath.Child("apiVersions").Index(i), ""))
+		}
+	}
+	return append(allErrs, validateResourcesNoSubresources(rule.Resources, fldPath.Child("resources"))...)
+}
+
+// Initializers, unlike webhooks, never supported subresources.
+func validateResourcesNoSubresources(resources []string, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+	if len(resources) == 0 {
+		allErrs = append(allErrs, field.Required(fldPath, ""))
+	}
+	for i, resource := range resources {
+		if resource == "" {
+			allErrs = append(allErrs, field.Required(fldPath.Index(i), ""))
+		}
+		if strings.Contains(resource, "/") {
+			allErrs = append(allErrs, field.Invalid(fldPath.Index(i), resource, "must not specify subresources"))
+		}
+	}
+	if len(resources) > 1 && contains(resources, wildcard) {
+		allErrs = append(allErrs, field.Invalid(fldPath, resources, "if '*' is present, must not specify other resources"))
+	}
+	return allErrs
+}
+
+// ValidateServedResources flags concrete rule resources that no matching
+// group/version in served provides. Wildcard resources are skipped.
+func ValidateServedResources(cfg InitializerConfiguration, served map[schema.GroupVersion][]string) field.ErrorList {
+	var allErrs field.ErrorList
+	for i, initializer := range cfg.Initializers {
+		for j, rule := range initializer.Rules {
+			for k, resource := range rule.Resources {
+				if strings.Contains(resource, wildcard) {
+					continue
+				}
+				if !ruleServes(rule, resource, served) {
+					allErrs = append(allErrs, field.Invalid(rulePath(i, j).Child("resources").Index(k), resource,
+						fmt.Sprintf("not served by any of apiGroups %q, apiVersions %q", rule.APIGroups, rule.APIVersions)))
+				}
+			}
+		}
+	}
+	return allErrs
+}
+
+func ruleServes(rule Rule, resource string, served map[schema.GroupVersion][]string) bool {
+	for gv, resources := range served {
+		if matchesValue(rule.APIGroups, gv.Group) && matchesValue(rule.APIVersions, gv.Version) && contains(resources, resource) {
+			return true
+		}
+	}
+	return false
+}
+
+func matchesValue(values []string, value string) bool {
+	return contains(values, wildcard) || contains(values, value)
+}
+
+func initializerPath(initializer int) *field.Path {
+	return field.NewPath("initializers").Index(initializer)
+}
+
+func rulePath(initializer, rule int) *field.Path {
+	return initializerPath(initializer).Child("rules").Index(rule)
+}
+
+// ValidateNoSelfReference flags rules that would have initializers run on
+// InitializerConfiguration objects themselves.
+func ValidateNoSelfReference(cfg InitializerConfiguration) field.ErrorList {
+	self := SchemeGroupVersion.WithResource(Resource)
+	var allErrs field.ErrorList
+	for i, initializer := range cfg.Initializers {
+		for j, rule := range initializer.Rules {
+			if RuleMatches(rule, self) {
+				allErrs = append(allErrs, field.Forbidden(rulePath(i, j), fmt.Sprintf("must not match %s", self.GroupResource())))
+			}
+		}
+	}
+	return allErrs
+}
+
+// ValidateResourceCasing flags resources containing uppercase letters, with
+// paths relative to the rule.
+func ValidateResourceCasing(r Rule) field.ErrorList {
+	var allErrs field.ErrorList
+	for i, resource := range r.Resources {
+		if strings.ToLower(resource) != resource {
+			allErrs = append(allErrs, field.Invalid(field.NewPath("resources").Index(i), resource, "must be lowercase"))
+		}
+	}
+	return allErrs
+}
+
+// ValidateNoResourceOverlap flags resources already covered by a wildcard
+// entry of the same rule, such as deployments/scale next to */scale or pods
+// next to *. Paths are relative to the rule.
+func ValidateNoResourceOverlap(r Rule) field.ErrorList {
+	var allErrs field.ErrorList
+	for i, resource := range r.Resources {
+		for _, pattern := range r.Resources {
+			if pattern == resource || !strings.Contains(pattern, wildcard) {
+				continue
+			}
+			subsumed := pattern == allWithSubresources
+			if !strings.Contains(resource, wildcard) {
+				subsumed = subsumed || resourceMatches(pattern, resource)
+			}
+			if subsumed {
+				allErrs = append(allErrs, field.Invalid(field.NewPath("resources").Index(i), resource, fmt.Sprintf("if '%s' is present, must not specify %s", pattern, resource)))
+				break
+			}
+		}
+	}
+	return allErrs
+}
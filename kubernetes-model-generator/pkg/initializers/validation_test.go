@@ -0,0 +1,110 @@
+/**
+ * Copyright (C) 2015 Red Hat, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *         http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package initializers
+
+import (
+	"reflect"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+func TestValidateServedResources(t *testing.T) {
+	served := map[schema.GroupVersion][]string{
+		{Group: "", Version: "v1"}:     {"pods", "services"},
+		{Group: "apps", Version: "v1"}: {"deployments"},
+	}
+	cfg := InitializerConfiguration{Initializers: []Initializer{{
+		Name: "a.example.com",
+		Rules: []Rule{
+			{APIGroups: []string{""}, APIVersions: []string{"v1"}, Resources: []string{"pods", "widgets", "*"}},
+			{APIGroups: []string{"*"}, APIVersions: []string{"v1"}, Resources: []string{"deployments", "*/status"}},
+		},
+	}}}
+	errs := ValidateServedResources(cfg, served)
+	if len(errs) != 1 {
+		t.Fatalf("expected 1 error, got %v", errs)
+	}
+	if errs[0].Type != field.ErrorTypeInvalid || errs[0].Field != "initializers[0].rules[0].resources[1]" || errs[0].BadValue != "widgets" {
+		t.Errorf("unexpected error %v", errs[0])
+	}
+}
+
+func TestValidateNoSelfReference(t *testing.T) {
+	cfg := InitializerConfiguration{Initializers: []Initializer{
+		{Name: "a.example.com", Rules: []Rule{
+			{APIGroups: []string{""}, APIVersions: []string{"v1"}, Resources: []string{"pods"}},
+			{APIGroups: []string{"admissionregistration.k8s.io"}, APIVersions: []string{"v1alpha1"}, Resources: []string{"initializerconfigurations"}},
+		}},
+		{Name: "b.example.com", Rules: []Rule{
+			{APIGroups: []string{"*"}, APIVersions: []string{"*"}, Resources: []string{"*"}},
+		}},
+	}}
+	errs := ValidateNoSelfReference(cfg)
+	if len(errs) != 2 {
+		t.Fatalf("expected 2 errors, got %v", errs)
+	}
+	for i, want := range []string{"initializers[0].rules[1]", "initializers[1].rules[0]"} {
+		if errs[i].Type != field.ErrorTypeForbidden || errs[i].Field != want {
+			t.Errorf("expected a forbidden error at %s, got %v", want, errs[i])
+		}
+	}
+
+	benign := InitializerConfiguration{Initializers: []Initializer{{Name: "a.example.com", Rules: []Rule{
+		{APIGroups: []string{"admissionregistration.k8s.io"}, APIVersions: []string{"v1beta1"}, Resources: []string{"mutatingwebhookconfigurations"}},
+		{APIGroups: []string{"*"}, APIVersions: []string{"*"}, Resources: []string{"initializerconfigurations/status"}},
+	}}}}
+	if errs := ValidateNoSelfReference(benign); len(errs) != 0 {
+		t.Errorf("expected no errors, got %v", errs)
+	}
+}
+
+func TestValidateResourceCasing(t *testing.T) {
+	errs := ValidateResourceCasing(Rule{Resources: []string{"pods", "Pods", "*", "deployments/Scale", "*/*"}})
+	if len(errs) != 2 {
+		t.Fatalf("expected 2 errors, got %v", errs)
+	}
+	for i, want := range []string{"resources[1]", "resources[3]"} {
+		if errs[i].Type != field.ErrorTypeInvalid || errs[i].Field != want {
+			t.Errorf("expected an invalid value at %s, got %v", want, errs[i])
+		}
+	}
+}
+
+func TestValidateNoResourceOverlap(t *testing.T) {
+	tests := []struct {
+		resources []string
+		want      []string
+	}{
+		{resources: []string{"*/scale", "deployments/scale"}, want: []string{"resources[1]"}},
+		{resources: []string{"pods", "*"}, want: []string{"resources[0]"}},
+		{resources: []string{"pods/*", "pods/log"}, want: []string{"resources[1]"}},
+		{resources: []string{"*/*", "pods", "*/scale"}, want: []string{"resources[1]", "resources[2]"}},
+		{resources: []string{"*", "pods/log", "*/scale"}},
+		{resources: []string{"pods", "deployments/scale"}},
+	}
+	for _, tc := range tests {
+		errs := ValidateNoResourceOverlap(Rule{Resources: tc.resources})
+		var got []string
+		for _, err := range errs {
+			got = append(got, err.Field)
+		}
+		if !reflect.DeepEqual(got, tc.want) {
+			t.Errorf("%v: expected errors at %v, got %v", tc.resources, tc.want, errs)
+		}
+	}
+}
@@ -0,0 +1,217 @@
+/**
+ * Copyright (C) 2015 Red Hat, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *         http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package initializers
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+)
+
+type ManifestEventType string
+
+const (
+	ManifestAdded    ManifestEventType = "Added"
+	ManifestModified ManifestEventType = "Modified"
+	ManifestDeleted  ManifestEventType = "Deleted"
+	ManifestError    ManifestEventType = "Error"
+)
+
+// manifestDebounce is how long a manifest must stay untouched after a write
+// before it is read, so editors saving in several steps produce one event.
+const manifestDebounce = 100 * time.Millisecond
+
+// ManifestEvent reports a change to a manifest file. Err is set when the file
+// could not be read or decoded, or, for ManifestError, when watching failed.
+type ManifestEvent struct {
+	Type   ManifestEventType
+	Path   string
+	Config InitializerConfiguration
+	Err    error
+}
+
+// WatchManifestDir emits an event for every .yaml, .yml and .json manifest in
+// dir, first as Added for the files already present and then as they change.
+// The channel is closed once ctx is done.
+func WatchManifestDir(ctx context.Context, dir string) (<-chan ManifestEvent, error) {
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return nil, err
+	}
+	if err := watcher.Add(dir); err != nil {
+		watcher.Close()
+		return nil, err
+	}
+	entries, err := ioutil.ReadDir(dir)
+	if err != nil {
+		watcher.Close()
+		return nil, err
+	}
+	w := newManifestWatcher(watcher)
+	go w.run(ctx, dir, entries)
+	return w.events, nil
+}
+
+func newManifestWatcher(watcher *fsnotify.Watcher) *manifestWatcher {
+	return &manifestWatcher{
+		watcher: watcher,
+		events:  make(chan ManifestEvent),
+		ready:   make(chan debounced),
+		done:    make(chan struct{}),
+		pending: make(map[string]debounced),
+		known:   make(map[string][]byte),
+	}
+}
+
+type manifestWatcher struct {
+	watcher *fsnotify.Watcher
+	events  chan ManifestEvent
+	pending map[string]debounced
+	// generation numbers debounce timers so a timer that fired after being
+	// replaced can be told apart from the current one.
+	generation uint64
+	// ready receives the debounce timers that expired.
+	ready chan debounced
+	// done is closed when run returns, so timers firing late do not block.
+	done chan struct{}
+	// known holds the last contents read for every manifest reported.
+	known map[string][]byte
+}
+
+func (w *manifestWatcher) run(ctx context.Context, dir string, entries []os.FileInfo) {
+	defer close(w.events)
+	defer close(w.done)
+	defer w.watcher.Close()
+	defer func() {
+		for _, p := range w.pending {
+			p.timer.Stop()
+		}
+	}()
+
+	for _, entry := range entries {
+		path := filepath.Join(dir, entry.Name())
+		if !entry.IsDir() && isManifest(path) && !w.sync(ctx, path) {
+			return
+		}
+	}
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case event, ok := <-w.watcher.Events:
+			if !ok {
+				return
+			}
+			if event.Op == fsnotify.Chmod || !isManifest(event.Name) {
+				continue
+			}
+			w.schedule(ctx, event.Name)
+		case err, ok := <-w.watcher.Errors:
+			if !ok {
+				return
+			}
+			if !w.send(ctx, ManifestEvent{Type: ManifestError, Path: dir, Err: err}) {
+				return
+			}
+		case fired := <-w.ready:
+			if current, ok := w.pending[fired.path]; !ok || current.generation != fired.generation {
+				continue
+			}
+			delete(w.pending, fired.path)
+			if !w.sync(ctx, fired.path) {
+				return
+			}
+		}
+	}
+}
+
+func (w *manifestWatcher) schedule(ctx context.Context, path string) {
+	if p, ok := w.pending[path]; ok {
+		p.timer.Stop()
+	}
+	w.generation++
+	fired := debounced{path: path, generation: w.generation}
+	p := fired
+	p.timer = time.AfterFunc(manifestDebounce, func() {
+		select {
+		case w.ready <- fired:
+		case <-w.done:
+		case <-ctx.Done():
+		}
+	})
+	w.pending[path] = p
+}
+
+// debounced is a pending read of path, scheduled as the given generation.
+type debounced struct {
+	path       string
+	generation uint64
+	timer      *time.Timer
+}
+
+// sync reads path and reports how it changed since it was last seen. It
+// returns false if ctx was done before the event could be delivered.
+func (w *manifestWatcher) sync(ctx context.Context, path string) bool {
+	previous, seen := w.known[path]
+	data, err := ioutil.ReadFile(path)
+	if os.IsNotExist(err) {
+		if !seen {
+			return true
+		}
+		delete(w.known, path)
+		return w.send(ctx, ManifestEvent{Type: ManifestDeleted, Path: path})
+	}
+	event := ManifestEvent{Type: ManifestAdded, Path: path}
+	if seen {
+		event.Type = ManifestModified
+	}
+	if err != nil {
+		event.Err = err
+		return w.send(ctx, event)
+	}
+	if seen && bytes.Equal(previous, data) {
+		return true
+	}
+	w.known[path] = data
+	event.Config, err = DecodeWithPositions(data)
+	if err != nil {
+		event.Err = fmt.Errorf("%s: %v", path, err)
+	}
+	return w.send(ctx, event)
+}
+
+func (w *manifestWatcher) send(ctx context.Context, event ManifestEvent) bool {
+	select {
+	case w.events <- event:
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
+
+func isManifest(path string) bool {
+	switch filepath.Ext(path) {
+	case ".yaml", ".yml", ".json":
+		return true
+	}
+	return false
+}
@@ -0,0 +1,147 @@
+/**
+ * Copyright (C) 2015 Red Hat, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *         http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package initializers
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+)
+
+func writeManifest(t *testing.T, path, name string) {
+	t.Helper()
+	data := []byte("apiVersion: admissionregistration.k8s.io/v1alpha1\nkind: InitializerConfiguration\nmetadata:\n  name: " + name + "\n")
+	if err := ioutil.WriteFile(path, data, 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func nextEvent(t *testing.T, events <-chan ManifestEvent) ManifestEvent {
+	t.Helper()
+	select {
+	case event, ok := <-events:
+		if !ok {
+			t.Fatal("events closed early")
+		}
+		return event
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for an event")
+	}
+	return ManifestEvent{}
+}
+
+func expectEvent(t *testing.T, events <-chan ManifestEvent, eventType ManifestEventType, path, name string) {
+	t.Helper()
+	event := nextEvent(t, events)
+	if event.Type != eventType || event.Path != path || event.Err != nil || event.Config.Name != name {
+		t.Fatalf("expected %s of %s named %q, got %+v", eventType, path, name, event)
+	}
+}
+
+func TestWatchManifestDir(t *testing.T) {
+	dir, err := ioutil.TempDir("", "initializers-watch")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	existing := filepath.Join(dir, "existing.yaml")
+	writeManifest(t, existing, "existing")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	events, err := WatchManifestDir(ctx, dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expectEvent(t, events, ManifestAdded, existing, "existing")
+
+	path := filepath.Join(dir, "config.yaml")
+	writeManifest(t, path, "created")
+	expectEvent(t, events, ManifestAdded, path, "created")
+
+	writeManifest(t, path, "modified")
+	expectEvent(t, events, ManifestModified, path, "modified")
+
+	// Writes within the debounce window are reported once, with the final
+	// contents.
+	writeManifest(t, path, "first")
+	writeManifest(t, path, "second")
+	expectEvent(t, events, ManifestModified, path, "second")
+
+	if err := ioutil.WriteFile(filepath.Join(dir, "notes.txt"), []byte("ignored"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Remove(path); err != nil {
+		t.Fatal(err)
+	}
+	event := nextEvent(t, events)
+	if event.Type != ManifestDeleted || event.Path != path {
+		t.Fatalf("expected %s of %s, got %+v", ManifestDeleted, path, event)
+	}
+
+	select {
+	case event := <-events:
+		t.Fatalf("unexpected event %+v", event)
+	case <-time.After(3 * manifestDebounce):
+	}
+
+	cancel()
+	select {
+	case _, ok := <-events:
+		if ok {
+			t.Fatal("expected events to be closed")
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for events to close")
+	}
+}
+
+func TestWatchManifestDirIgnoresStaleTimers(t *testing.T) {
+	dir, err := ioutil.TempDir("", "initializers-watch")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := watcher.Add(dir); err != nil {
+		watcher.Close()
+		t.Fatal(err)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	w := newManifestWatcher(watcher)
+	go w.run(ctx, dir, nil)
+
+	path := filepath.Join(dir, "config.yaml")
+	writeManifest(t, path, "created")
+	// A timer that fired just as it was replaced still delivers; generation 0
+	// is never current, so it must not cause an early read.
+	w.ready <- debounced{path: path}
+	select {
+	case event := <-w.events:
+		t.Fatalf("unexpected event %+v before the debounce expired", event)
+	case <-time.After(manifestDebounce / 4):
+	}
+	expectEvent(t, w.events, ManifestAdded, path, "created")
+}
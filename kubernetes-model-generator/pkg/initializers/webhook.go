@@ -0,0 +1,139 @@
+/**
+ * Copyright (C) 2015 Red Hat, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *         http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package initializers
+
+import (
+	"bytes"
+	"fmt"
+
+	"gopkg.in/yaml.v3"
+	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8syaml "sigs.k8s.io/yaml"
+)
+
+// webhookPlaceholder marks the client config values a migration leaves to the
+// user.
+const webhookPlaceholder = "TODO"
+
+// ToValidatingWebhookConfiguration converts cfg into a webhook configuration
+// with one webhook per initializer, named after it and sharing its rules.
+// Initializers only ran when objects were created, so the webhooks only
+// intercept CREATE. Client configs are left empty for the caller to fill in.
+func ToValidatingWebhookConfiguration(cfg InitializerConfiguration) admissionregistrationv1.ValidatingWebhookConfiguration {
+	failurePolicy := admissionregistrationv1.Fail
+	sideEffects := admissionregistrationv1.SideEffectClassNone
+	webhooks := make([]admissionregistrationv1.ValidatingWebhook, 0, len(cfg.Initializers))
+	for _, initializer := range cfg.Initializers {
+		rules := make([]admissionregistrationv1.RuleWithOperations, 0, len(initializer.Rules))
+		for _, rule := range initializer.Rules {
+			rules = append(rules, admissionregistrationv1.RuleWithOperations{
+				Operations: []admissionregistrationv1.OperationType{admissionregistrationv1.Create},
+				Rule: admissionregistrationv1.Rule{
+					APIGroups:   copyStrings(rule.APIGroups),
+					APIVersions: copyStrings(rule.APIVersions),
+					Resources:   copyStrings(rule.Resources),
+				},
+			})
+		}
+		webhooks = append(webhooks, admissionregistrationv1.ValidatingWebhook{
+			Name:                    initializer.Name,
+			Rules:                   rules,
+			FailurePolicy:           &failurePolicy,
+			SideEffects:             &sideEffects,
+			AdmissionReviewVersions: []string{"v1"},
+		})
+	}
+	return admissionregistrationv1.ValidatingWebhookConfiguration{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: admissionregistrationv1.SchemeGroupVersion.String(),
+			Kind:       "ValidatingWebhookConfiguration",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        cfg.Name,
+			Labels:      cfg.Labels,
+			Annotations: cfg.Annotations,
+		},
+		Webhooks: webhooks,
+	}
+}
+
+// GenerateWebhookMigration returns a commented YAML manifest of the
+// ToValidatingWebhookConfiguration of cfg, ready to be edited and applied.
+// Every webhook points at a TODO service the user must replace.
+func GenerateWebhookMigration(cfg InitializerConfiguration) (string, error) {
+	webhookConfig := ToValidatingWebhookConfiguration(cfg)
+	for i := range webhookConfig.Webhooks {
+		webhookConfig.Webhooks[i].ClientConfig.Service = &admissionregistrationv1.ServiceReference{
+			Namespace: webhookPlaceholder,
+			Name:      webhookPlaceholder,
+		}
+	}
+	data, err := k8syaml.Marshal(webhookConfig)
+	if err != nil {
+		return "", fmt.Errorf("encoding webhook configuration: %v", err)
+	}
+
+	// Comments are attached through the node tree, as the typed encoding has
+	// no place for them.
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return "", fmt.Errorf("parsing webhook configuration: %v", err)
+	}
+	doc.HeadComment = fmt.Sprintf("Migrated from %s %s.\nInitializers only ran on creation, so every webhook intercepts CREATE only.", Kind, cfg.Name)
+	if webhooks := mappingValue(doc.Content[0], "webhooks"); webhooks != nil {
+		for _, webhook := range webhooks.Content {
+			if key := mappingKey(webhook, "clientConfig"); key != nil {
+				key.HeadComment = "TODO: set the namespace and name of the webhook service, or replace service with a url."
+			}
+		}
+	}
+
+	var buf bytes.Buffer
+	encoder := yaml.NewEncoder(&buf)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(&doc); err != nil {
+		return "", fmt.Errorf("encoding webhook migration: %v", err)
+	}
+	if err := encoder.Close(); err != nil {
+		return "", fmt.Errorf("encoding webhook migration: %v", err)
+	}
+	return buf.String(), nil
+}
+
+func mappingKey(node *yaml.Node, key string) *yaml.Node {
+	if node.Kind != yaml.MappingNode {
+		return nil
+	}
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == key {
+			return node.Content[i]
+		}
+	}
+	return nil
+}
+
+func mappingValue(node *yaml.Node, key string) *yaml.Node {
+	if node.Kind != yaml.MappingNode {
+		return nil
+	}
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == key {
+			return node.Content[i+1]
+		}
+	}
+	return nil
+}
@@ -0,0 +1,84 @@
+/**
+ * Copyright (C) 2015 Red Hat, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *         http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package initializers
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8syaml "sigs.k8s.io/yaml"
+)
+
+func TestGenerateWebhookMigration(t *testing.T) {
+	cfg := InitializerConfiguration{
+		ObjectMeta: metav1.ObjectMeta{Name: "example"},
+		Initializers: []Initializer{
+			{Name: "podimage.example.com", Rules: []Rule{
+				{APIGroups: []string{""}, APIVersions: []string{"v1"}, Resources: []string{"pods"}},
+				{APIGroups: []string{"apps"}, APIVersions: []string{"v1", "v1beta1"}, Resources: []string{"deployments"}},
+			}},
+			{Name: "all.example.com", Rules: []Rule{
+				{APIGroups: []string{"*"}, APIVersions: []string{"*"}, Resources: []string{"*"}},
+			}},
+		},
+	}
+	manifest, err := GenerateWebhookMigration(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(manifest, "# Migrated from InitializerConfiguration example.") {
+		t.Errorf("expected a header comment, got\n%s", manifest)
+	}
+	if n := strings.Count(manifest, "# TODO: "); n != 2 {
+		t.Errorf("expected a TODO comment per webhook, got %d in\n%s", n, manifest)
+	}
+
+	var webhookConfig admissionregistrationv1.ValidatingWebhookConfiguration
+	if err := k8syaml.UnmarshalStrict([]byte(manifest), &webhookConfig); err != nil {
+		t.Fatalf("decoding\n%s: %v", manifest, err)
+	}
+	if webhookConfig.Kind != "ValidatingWebhookConfiguration" || webhookConfig.APIVersion != "admissionregistration.k8s.io/v1" || webhookConfig.Name != "example" {
+		t.Errorf("unexpected type or object meta %+v %+v", webhookConfig.TypeMeta, webhookConfig.ObjectMeta)
+	}
+	if len(webhookConfig.Webhooks) != len(cfg.Initializers) {
+		t.Fatalf("expected %d webhooks, got %d", len(cfg.Initializers), len(webhookConfig.Webhooks))
+	}
+	for i, webhook := range webhookConfig.Webhooks {
+		initializer := cfg.Initializers[i]
+		if webhook.Name != initializer.Name {
+			t.Errorf("webhook %d: expected name %s, got %s", i, initializer.Name, webhook.Name)
+		}
+		service := webhook.ClientConfig.Service
+		if service == nil || service.Namespace != "TODO" || service.Name != "TODO" || webhook.ClientConfig.URL != nil {
+			t.Errorf("webhook %d: expected a TODO service, got %+v", i, webhook.ClientConfig)
+		}
+		if len(webhook.Rules) != len(initializer.Rules) {
+			t.Fatalf("webhook %d: expected %d rules, got %d", i, len(initializer.Rules), len(webhook.Rules))
+		}
+		for j, rule := range webhook.Rules {
+			want := initializer.Rules[j]
+			if !reflect.DeepEqual(rule.APIGroups, want.APIGroups) || !reflect.DeepEqual(rule.APIVersions, want.APIVersions) || !reflect.DeepEqual(rule.Resources, want.Resources) {
+				t.Errorf("webhook %d rule %d: expected %+v, got %+v", i, j, want, rule.Rule)
+			}
+			if !reflect.DeepEqual(rule.Operations, []admissionregistrationv1.OperationType{admissionregistrationv1.Create}) {
+				t.Errorf("webhook %d rule %d: expected CREATE only, got %v", i, j, rule.Operations)
+			}
+		}
+	}
+}
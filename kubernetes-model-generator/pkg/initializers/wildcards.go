@@ -0,0 +1,71 @@
+/**
+ * Copyright (C) 2015 Red Hat, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *         http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package initializers
+
+const (
+	wildcard            = "*"
+	allWithSubresources = "*/*"
+)
+
+// FixWildcards collapses rule slices that mix a wildcard with other entries,
+// returning the number of slices it rewrote.
+func FixWildcards(cfg *InitializerConfiguration) (fixed int) {
+	for i := range cfg.Initializers {
+		rules := cfg.Initializers[i].Rules
+		for j := range rules {
+			if collapseWildcard(&rules[j].APIGroups) {
+				fixed++
+			}
+			if collapseWildcard(&rules[j].APIVersions) {
+				fixed++
+			}
+			if collapseResourceWildcard(&rules[j].Resources) {
+				fixed++
+			}
+		}
+	}
+	return fixed
+}
+
+func collapseWildcard(values *[]string) bool {
+	if len(*values) < 2 || !contains(*values, wildcard) {
+		return false
+	}
+	*values = []string{wildcard}
+	return true
+}
+
+// Initializers never match subresources, so "*/*" means the same as "*" and
+// any wildcard leaves just ["*"], the only form validation accepts.
+func collapseResourceWildcard(resources *[]string) bool {
+	if len(*resources) == 1 && (*resources)[0] == wildcard {
+		return false
+	}
+	if !contains(*resources, wildcard) && !contains(*resources, allWithSubresources) {
+		return false
+	}
+	*resources = []string{wildcard}
+	return true
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,88 @@
+/**
+ * Copyright (C) 2015 Red Hat, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *         http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package initializers
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestFixWildcards(t *testing.T) {
+	tests := []struct {
+		name      string
+		rule      Rule
+		want      Rule
+		wantFixed int
+	}{
+		{
+			name:      "wildcard resource among concrete ones",
+			rule:      Rule{APIGroups: []string{""}, APIVersions: []string{"v1"}, Resources: []string{"pods", "*", "services"}},
+			want:      Rule{APIGroups: []string{""}, APIVersions: []string{"v1"}, Resources: []string{"*"}},
+			wantFixed: 1,
+		},
+		{
+			name:      "wildcard group",
+			rule:      Rule{APIGroups: []string{"apps", "*"}, APIVersions: []string{"v1"}, Resources: []string{"deployments"}},
+			want:      Rule{APIGroups: []string{"*"}, APIVersions: []string{"v1"}, Resources: []string{"deployments"}},
+			wantFixed: 1,
+		},
+		{
+			name:      "all resources and subresources",
+			rule:      Rule{APIGroups: []string{""}, APIVersions: []string{"v1"}, Resources: []string{"*/*", "pods"}},
+			want:      Rule{APIGroups: []string{""}, APIVersions: []string{"v1"}, Resources: []string{"*"}},
+			wantFixed: 1,
+		},
+		{
+			name:      "lone all resources and subresources",
+			rule:      Rule{APIGroups: []string{""}, APIVersions: []string{"v1"}, Resources: []string{"*/*"}},
+			want:      Rule{APIGroups: []string{""}, APIVersions: []string{"v1"}, Resources: []string{"*"}},
+			wantFixed: 1,
+		},
+		{
+			name:      "subresources next to wildcard",
+			rule:      Rule{APIGroups: []string{""}, APIVersions: []string{"v1"}, Resources: []string{"pods", "*", "pods/status"}},
+			want:      Rule{APIGroups: []string{""}, APIVersions: []string{"v1"}, Resources: []string{"*"}},
+			wantFixed: 1,
+		},
+		{
+			name:      "every field",
+			rule:      Rule{APIGroups: []string{"*", "apps"}, APIVersions: []string{"v1", "*"}, Resources: []string{"*", "deployments"}},
+			want:      Rule{APIGroups: []string{"*"}, APIVersions: []string{"*"}, Resources: []string{"*"}},
+			wantFixed: 3,
+		},
+		{
+			name:      "already well formed",
+			rule:      Rule{APIGroups: []string{"*"}, APIVersions: []string{"v1"}, Resources: []string{"*"}},
+			want:      Rule{APIGroups: []string{"*"}, APIVersions: []string{"v1"}, Resources: []string{"*"}},
+			wantFixed: 0,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			cfg := &InitializerConfiguration{Initializers: []Initializer{{Name: "a.example.com", Rules: []Rule{tc.rule}}}}
+			if fixed := FixWildcards(cfg); fixed != tc.wantFixed {
+				t.Errorf("expected %d fixed slices, got %d", tc.wantFixed, fixed)
+			}
+			if got := cfg.Initializers[0].Rules[0]; !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("expected %#v, got %#v", tc.want, got)
+			}
+			cfg.Name = "example"
+			if errs := ValidateInitializerConfiguration(*cfg); len(errs) != 0 {
+				t.Errorf("expected fixed config to validate, got %v", errs)
+			}
+		})
+	}
+}